@@ -7,6 +7,7 @@ import (
 	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/delete"
 	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/get"
 	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/icsp"
+	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/image_mirror_set"
 	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/install"
 	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/jaeger"
 	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/migrate"
@@ -14,4 +15,5 @@ import (
 	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/registry"
 	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/root"
 	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/uninstall"
+	_ "github.com/jkandasa/autoeasy/cmd/plugin/openshift/upgrade"
 )
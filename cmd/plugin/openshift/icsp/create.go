@@ -6,13 +6,14 @@ import (
 	openshiftCreateCmd "github.com/jkandasa/autoeasy/cmd/plugin/openshift/create"
 	rootCmd "github.com/jkandasa/autoeasy/cmd/root"
 	icspAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/image_content_source_policy"
-	nodeAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/node"
+	mcpAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/machine_config_pool"
 	openshiftClient "github.com/jkandasa/autoeasy/plugin/provider/openshift/client"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	"github.com/openshift/api/operator/v1alpha1"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
@@ -22,9 +23,9 @@ var (
 	createForceRecreate bool
 
 	nodeReadyTimeout = openshiftTY.TimeoutConfig{
-		Timeout:              time.Minute * 10,
+		Timeout:              time.Minute * 30,
 		ScanInterval:         time.Second * 20,
-		ExpectedSuccessCount: 5,
+		ExpectedSuccessCount: 3,
 	}
 )
 
@@ -32,7 +33,7 @@ func init() {
 	openshiftCreateCmd.AddCommand(createIcspCmd)
 	createIcspCmd.Flags().StringVar(&createSource, "source", "registry.redhat.io", "source registry")
 	createIcspCmd.Flags().StringSliceVar(&createMirrors, "mirror", []string{}, "comma separated mirror registries. registry1,registry2")
-	createIcspCmd.Flags().BoolVar(&waitForNodeReady, "wait-for-node-ready", false, "waits until the nodes are ready to schedule")
+	createIcspCmd.Flags().BoolVar(&waitForNodeReady, "wait-for-node-ready", false, "waits until the machine config pools are rolled out")
 	createIcspCmd.Flags().BoolVar(&createForceRecreate, "force", false, "deletes the icsp if exists and creates")
 }
 
@@ -51,9 +52,12 @@ var createIcspCmd = &cobra.Command{
 		// get kubernetes client
 		k8sClient := openshiftClient.GetKubernetesClient()
 
+		// target configurations of the pools before the change
+		snapshot := getSnapshot(k8sClient)
+
 		// deletes icsp if recreate enabled
 		if createForceRecreate {
-			err := deleteIcsp(k8sClient, icspName, false)
+			_, err := deleteIcsp(k8sClient, icspName, false)
 			if err != nil {
 				zap.L().Error("error on deleting an ImageContentSourcePolicy", zap.Any("name", icspName[0]), zap.Error(err))
 				rootCmd.ExitWithError()
@@ -83,13 +87,30 @@ var createIcspCmd = &cobra.Command{
 
 		zap.L().Info("ImageContentSourcePolicy created", zap.String("name", icspName[0]))
 		if waitForNodeReady {
-			zap.L().Info("wait for node ready enabled")
-			err = nodeAPI.WaitForNodesReady(k8sClient, nodeReadyTimeout)
-			if err != nil {
-				zap.L().Error("error on waiting to node ready state", zap.Error(err))
-				rootCmd.ExitWithError()
-			}
-			zap.L().Info("nodes are available to schedule")
+			waitForNodes(k8sClient, snapshot)
 		}
 	},
 }
+
+// returns the target configurations of the pools, if wait for node ready enabled
+func getSnapshot(k8sClient client.Client) map[string]string {
+	if !waitForNodeReady {
+		return nil
+	}
+	snapshot, err := mcpAPI.Snapshot(k8sClient, nil)
+	if err != nil {
+		zap.L().Error("error on getting the machine config pools", zap.Error(err))
+		rootCmd.ExitWithError()
+	}
+	return snapshot
+}
+
+func waitForNodes(k8sClient client.Client, snapshot map[string]string) {
+	zap.L().Info("wait for node ready enabled")
+	err := mcpAPI.WaitForRollout(k8sClient, nil, snapshot, nodeReadyTimeout)
+	if err != nil {
+		zap.L().Error("error on waiting for the machine config pools rollout", zap.Error(err))
+		rootCmd.ExitWithError()
+	}
+	zap.L().Info("machine config pools are rolled out")
+}
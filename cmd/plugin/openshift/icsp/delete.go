@@ -3,7 +3,7 @@ package icsp
 import (
 	openshiftDeleteCmd "github.com/jkandasa/autoeasy/cmd/plugin/openshift/delete"
 	icspAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/image_content_source_policy"
-	nodeAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/node"
+	mcpAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/machine_config_pool"
 
 	rootCmd "github.com/jkandasa/autoeasy/cmd/root"
 	openshiftClient "github.com/jkandasa/autoeasy/plugin/provider/openshift/client"
@@ -20,7 +20,7 @@ var (
 func init() {
 	openshiftDeleteCmd.AddCommand(deleteIcspCmd)
 	deleteIcspCmd.Flags().BoolVar(&deleteAll, "delete-all", false, "deletes all the imageContentSourcePolicy")
-	deleteIcspCmd.Flags().BoolVar(&waitForNodeReady, "wait-for-node-ready", false, "waits until the nodes are ready to schedule")
+	deleteIcspCmd.Flags().BoolVar(&waitForNodeReady, "wait-for-node-ready", false, "waits until the machine config pools are rolled out")
 
 }
 
@@ -37,51 +37,71 @@ var deleteIcspCmd = &cobra.Command{
 		// get kubernetes client
 		k8sClient := openshiftClient.GetKubernetesClient()
 
+		// target configurations of the pools before the change
+		snapshot := getSnapshot(k8sClient)
+
+		deletedCount := 0
 		if deleteAll {
-			err := icspAPI.DeleteOfAll(k8sClient, &v1alpha1.ImageContentSourcePolicy{}, []client.DeleteAllOfOption{})
+			installedList, err := icspAPI.List(k8sClient, []client.ListOption{})
+			if err != nil {
+				zap.L().Error("error on getting ImageContentSourcePolicy list", zap.Error(err))
+				rootCmd.ExitWithError()
+			}
+			deletedCount = len(installedList.Items)
+			err = icspAPI.DeleteOfAll(k8sClient, &v1alpha1.ImageContentSourcePolicy{}, []client.DeleteAllOfOption{})
 			if err != nil {
 				zap.L().Error("error on deleting all ImageContentSourcePolicy", zap.Error(err))
 				rootCmd.ExitWithError()
 			}
 		} else {
-			err := deleteIcsp(k8sClient, icspNameList, false)
+			count, err := deleteIcsp(k8sClient, icspNameList, false)
 			if err != nil {
 				zap.L().Error("error on deleting ImageContentSourcePolicy", zap.Any("names", icspNameList), zap.Error(err))
 				rootCmd.ExitWithError()
 			}
+			deletedCount = count
 		}
 
 		if waitForNodeReady {
-			zap.L().Info("wait for node ready enabled")
-			err := nodeAPI.WaitForNodesReady(k8sClient, nodeReadyTimeout)
-			if err != nil {
-				zap.L().Error("error on waiting to node ready state", zap.Error(err))
-				rootCmd.ExitWithError()
+			// without changes, waits only for the current rollout
+			if deletedCount == 0 {
+				snapshot = nil
 			}
-			zap.L().Info("nodes are available to schedule")
+			waitForNodes(k8sClient, snapshot)
 		}
 	},
 }
 
-func deleteIcsp(k8sClient client.Client, icspNameList []string, waitForNodeReady bool) error {
+// returns the number of deleted ImageContentSourcePolicy
+func deleteIcsp(k8sClient client.Client, icspNameList []string, waitForNodeReady bool) (int, error) {
 	installedList, err := icspAPI.List(k8sClient, []client.ListOption{})
 	if err != nil {
 		zap.L().Fatal("error on getting list", zap.Error(err))
-		return err
+		return 0, err
 	}
 
+	deletedCount := 0
+
 	// delete icsp
+	var snapshot map[string]string
 	for _, icspName := range icspNameList {
 		found := false
 		for _, icspInstalled := range installedList.Items {
 			if icspInstalled.Name == icspName {
 				found = true
 				zap.L().Debug("deleting an ImageContentSourcePolicy", zap.String("name", icspName))
+				if waitForNodeReady {
+					snapshot, err = mcpAPI.Snapshot(k8sClient, nil)
+					if err != nil {
+						return deletedCount, err
+					}
+				}
 				err := icspAPI.Delete(k8sClient, &icspInstalled)
 				if err != nil {
 					zap.L().Error("error on deleting an ImageContentSourcePolicy", zap.String("name", icspName), zap.Error(err))
 					continue
 				}
+				deletedCount++
 				zap.L().Info("deleted an ImageContentSourcePolicy", zap.String("name", icspInstalled.GetName()))
 			}
 		}
@@ -89,13 +109,13 @@ func deleteIcsp(k8sClient client.Client, icspNameList []string, waitForNodeReady
 			zap.L().Info("ImageContentSourcePolicy not available", zap.String("name", icspName))
 		} else if waitForNodeReady {
 			zap.L().Info("wait for node ready enabled")
-			err = nodeAPI.WaitForNodesReady(k8sClient, nodeReadyTimeout)
+			err = mcpAPI.WaitForRollout(k8sClient, nil, snapshot, nodeReadyTimeout)
 			if err != nil {
-				zap.L().Error("error on waiting to node ready state", zap.Error(err))
+				zap.L().Error("error on waiting for the machine config pools rollout", zap.Error(err))
 			} else {
-				zap.L().Info("nodes are available to schedule")
+				zap.L().Info("machine config pools are rolled out")
 			}
 		}
 	}
-	return nil
+	return deletedCount, nil
 }
@@ -0,0 +1,145 @@
+package imagemirrorset
+
+import (
+	"time"
+
+	openshiftCreateCmd "github.com/jkandasa/autoeasy/cmd/plugin/openshift/create"
+	rootCmd "github.com/jkandasa/autoeasy/cmd/root"
+	icspAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/image_content_source_policy"
+	mirrorSetAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/image_mirror_set"
+	mcpAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/machine_config_pool"
+	openshiftClient "github.com/jkandasa/autoeasy/plugin/provider/openshift/client"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	waitForNodeReady    bool
+	createSource        string
+	createMirrors       []string
+	createForceRecreate bool
+	createFromICSP      bool
+
+	nodeReadyTimeout = openshiftTY.TimeoutConfig{
+		Timeout:              time.Minute * 30,
+		ScanInterval:         time.Second * 20,
+		ExpectedSuccessCount: 3,
+	}
+)
+
+func init() {
+	openshiftCreateCmd.AddCommand(createIdmsCmd, createItmsCmd)
+	for _, cmd := range []*cobra.Command{createIdmsCmd, createItmsCmd} {
+		cmd.Flags().StringVar(&createSource, "source", "registry.redhat.io", "source registry")
+		cmd.Flags().StringSliceVar(&createMirrors, "mirror", []string{}, "comma separated mirror registries. registry1,registry2")
+		cmd.Flags().BoolVar(&waitForNodeReady, "wait-for-node-ready", false, "waits until the machine config pools are rolled out")
+		cmd.Flags().BoolVar(&createForceRecreate, "force", false, "deletes the mirror set if exists and creates")
+	}
+	createIdmsCmd.Flags().BoolVar(&createFromICSP, "from-icsp", false, "converts the existing ImageContentSourcePolicy with the same name")
+}
+
+var createIdmsCmd = &cobra.Command{
+	Use:     "idms",
+	Short:   "installs ImageDigestMirrorSet",
+	Aliases: []string{"imagedigestmirrorset"},
+	Args:    cobra.ExactArgs(1),
+	Example: `  # create with the mirrors
+  autoeasy openshift create idms my-mirrors --source registry.redhat.io --mirror registry.example.com
+
+  # convert an existing ImageContentSourcePolicy
+  autoeasy openshift create idms my-icsp --from-icsp --wait-for-node-ready`,
+	Run: func(cmd *cobra.Command, names []string) {
+		createMirrorSet(openshiftTY.KindImageDigestMirrorSet, names[0])
+	},
+}
+
+var createItmsCmd = &cobra.Command{
+	Use:     "itms",
+	Short:   "installs ImageTagMirrorSet",
+	Aliases: []string{"imagetagmirrorset"},
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, names []string) {
+		createMirrorSet(openshiftTY.KindImageTagMirrorSet, names[0])
+	},
+}
+
+func createMirrorSet(kind, name string) {
+	if !createFromICSP && len(createMirrors) == 0 {
+		zap.L().Error("mirror registries can not be empty")
+		rootCmd.ExitWithError()
+	}
+
+	// get kubernetes client
+	k8sClient := openshiftClient.GetKubernetesClient()
+
+	var mirrorSet *unstructured.Unstructured
+	var err error
+	if createFromICSP {
+		icsp, err := icspAPI.Get(k8sClient, name, "")
+		if err != nil {
+			zap.L().Error("error on getting an ImageContentSourcePolicy", zap.String("name", name), zap.Error(err))
+			rootCmd.ExitWithError()
+		}
+		mirrorSet, err = mirrorSetAPI.FromICSP(icsp)
+		if err != nil {
+			zap.L().Error("error on converting an ImageContentSourcePolicy", zap.String("name", name), zap.Error(err))
+			rootCmd.ExitWithError()
+		}
+	} else {
+		mirrors := []openshiftTY.ImageMirrors{{Source: createSource, Mirrors: createMirrors}}
+		mirrorSet, err = mirrorSetAPI.New(kind, name, mirrors)
+		if err != nil {
+			zap.L().Error("error on preparing a mirror set", zap.String("kind", kind), zap.String("name", name), zap.Error(err))
+			rootCmd.ExitWithError()
+		}
+	}
+
+	// target configurations of the pools before the change
+	snapshot := getSnapshot(k8sClient)
+
+	// deletes the mirror set if recreate enabled
+	if createForceRecreate {
+		_, err = deleteMirrorSet(k8sClient, kind, []string{name})
+		if err != nil {
+			zap.L().Error("error on deleting a mirror set", zap.String("kind", kind), zap.String("name", name), zap.Error(err))
+			rootCmd.ExitWithError()
+		}
+	}
+
+	err = mirrorSetAPI.Create(k8sClient, mirrorSet)
+	if err != nil {
+		zap.L().Error("error on creating a mirror set", zap.String("kind", kind), zap.String("name", name), zap.Error(err))
+		rootCmd.ExitWithError()
+	}
+
+	zap.L().Info("mirror set created", zap.String("kind", kind), zap.String("name", name))
+	if waitForNodeReady {
+		waitForNodes(k8sClient, snapshot)
+	}
+}
+
+// returns the target configurations of the pools, if wait for node ready enabled
+func getSnapshot(k8sClient client.Client) map[string]string {
+	if !waitForNodeReady {
+		return nil
+	}
+	snapshot, err := mcpAPI.Snapshot(k8sClient, nil)
+	if err != nil {
+		zap.L().Error("error on getting the machine config pools", zap.Error(err))
+		rootCmd.ExitWithError()
+	}
+	return snapshot
+}
+
+func waitForNodes(k8sClient client.Client, snapshot map[string]string) {
+	zap.L().Info("wait for node ready enabled")
+	err := mcpAPI.WaitForRollout(k8sClient, nil, snapshot, nodeReadyTimeout)
+	if err != nil {
+		zap.L().Error("error on waiting for the machine config pools rollout", zap.Error(err))
+		rootCmd.ExitWithError()
+	}
+	zap.L().Info("machine config pools are rolled out")
+}
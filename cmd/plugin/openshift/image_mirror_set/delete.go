@@ -0,0 +1,116 @@
+package imagemirrorset
+
+import (
+	openshiftDeleteCmd "github.com/jkandasa/autoeasy/cmd/plugin/openshift/delete"
+	rootCmd "github.com/jkandasa/autoeasy/cmd/root"
+	mirrorSetAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/image_mirror_set"
+	openshiftClient "github.com/jkandasa/autoeasy/plugin/provider/openshift/client"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	deleteAll bool
+)
+
+func init() {
+	openshiftDeleteCmd.AddCommand(deleteIdmsCmd, deleteItmsCmd)
+	for _, cmd := range []*cobra.Command{deleteIdmsCmd, deleteItmsCmd} {
+		cmd.Flags().BoolVar(&deleteAll, "delete-all", false, "deletes all the mirror sets")
+		cmd.Flags().BoolVar(&waitForNodeReady, "wait-for-node-ready", false, "waits until the machine config pools are rolled out")
+	}
+}
+
+var deleteIdmsCmd = &cobra.Command{
+	Use:     "idms",
+	Short:   "deletes ImageDigestMirrorSet",
+	Aliases: []string{"imagedigestmirrorset"},
+	Run: func(cmd *cobra.Command, names []string) {
+		runDelete(openshiftTY.KindImageDigestMirrorSet, names)
+	},
+}
+
+var deleteItmsCmd = &cobra.Command{
+	Use:     "itms",
+	Short:   "deletes ImageTagMirrorSet",
+	Aliases: []string{"imagetagmirrorset"},
+	Run: func(cmd *cobra.Command, names []string) {
+		runDelete(openshiftTY.KindImageTagMirrorSet, names)
+	},
+}
+
+func runDelete(kind string, names []string) {
+	if !deleteAll && len(names) == 0 {
+		zap.L().Error("at least a name required or enable --delete-all flag")
+		return
+	}
+
+	// get kubernetes client
+	k8sClient := openshiftClient.GetKubernetesClient()
+
+	// target configurations of the pools before the change
+	snapshot := getSnapshot(k8sClient)
+
+	deletedCount := 0
+	if deleteAll {
+		installedList, err := mirrorSetAPI.List(k8sClient, kind, []client.ListOption{})
+		if err != nil {
+			zap.L().Error("error on getting the mirror sets", zap.String("kind", kind), zap.Error(err))
+			rootCmd.ExitWithError()
+		}
+		deletedCount = len(installedList.Items)
+		err = mirrorSetAPI.DeleteOfAll(k8sClient, kind, []client.DeleteAllOfOption{})
+		if err != nil {
+			zap.L().Error("error on deleting all the mirror sets", zap.String("kind", kind), zap.Error(err))
+			rootCmd.ExitWithError()
+		}
+	} else {
+		count, err := deleteMirrorSet(k8sClient, kind, names)
+		if err != nil {
+			zap.L().Error("error on deleting mirror sets", zap.String("kind", kind), zap.Any("names", names), zap.Error(err))
+			rootCmd.ExitWithError()
+		}
+		deletedCount = count
+	}
+
+	if waitForNodeReady {
+		// without changes, waits only for the current rollout
+		if deletedCount == 0 {
+			snapshot = nil
+		}
+		waitForNodes(k8sClient, snapshot)
+	}
+}
+
+// returns the number of deleted mirror sets
+func deleteMirrorSet(k8sClient client.Client, kind string, names []string) (int, error) {
+	installedList, err := mirrorSetAPI.List(k8sClient, kind, []client.ListOption{})
+	if err != nil {
+		return 0, err
+	}
+
+	deletedCount := 0
+
+	for _, name := range names {
+		found := false
+		for _, installed := range installedList.Items {
+			if installed.GetName() == name {
+				found = true
+				zap.L().Debug("deleting a mirror set", zap.String("kind", kind), zap.String("name", name))
+				err := mirrorSetAPI.Delete(k8sClient, &installed)
+				if err != nil {
+					zap.L().Error("error on deleting a mirror set", zap.String("kind", kind), zap.String("name", name), zap.Error(err))
+					continue
+				}
+				deletedCount++
+				zap.L().Info("deleted a mirror set", zap.String("kind", kind), zap.String("name", name))
+			}
+		}
+		if !found {
+			zap.L().Info("mirror set not available", zap.String("kind", kind), zap.String("name", name))
+		}
+	}
+	return deletedCount, nil
+}
@@ -0,0 +1,72 @@
+package operator
+
+import (
+	"os"
+
+	openshiftGetCmd "github.com/jkandasa/autoeasy/cmd/plugin/openshift/get"
+	rootCmd "github.com/jkandasa/autoeasy/cmd/root"
+	csvAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/cluster_service_version"
+	openshiftClient "github.com/jkandasa/autoeasy/plugin/provider/openshift/client"
+	mcUtils "github.com/mycontroller-org/server/v2/pkg/utils"
+	"github.com/mycontroller-org/server/v2/pkg/utils/printer"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	getOperatorsNamespace string
+)
+
+func init() {
+	openshiftGetCmd.AddCommand(getOperatorsCmd)
+	getOperatorsCmd.Flags().StringVar(&getOperatorsNamespace, "namespace", "", "namespace of the operators. all the namespaces, if empty")
+}
+
+var getOperatorsCmd = &cobra.Command{
+	Use:     "operators",
+	Aliases: []string{"operator"},
+	Short:   "lists installed operators",
+	Example: `  # list all the installed operators
+  autoeasy openshift get operators
+
+  # list the given operators from a namespace
+  autoeasy openshift get operators jaeger-product --namespace openshift-operators -o wide`,
+	Run: func(cmd *cobra.Command, operatorsList []string) {
+		// get kubernetes client
+		k8sClient := openshiftClient.GetKubernetesClient()
+
+		operators, err := csvAPI.Info(k8sClient, getOperatorsNamespace)
+		if err != nil {
+			zap.L().Error("error on getting operators list", zap.String("namespace", getOperatorsNamespace), zap.Error(err))
+			rootCmd.ExitWithError()
+		}
+
+		headers := []printer.Header{
+			{Title: "namespace", ValuePath: "namespace"},
+			{Title: "name", ValuePath: "name"},
+			{Title: "display name", ValuePath: "displayName", IsWide: true},
+			{Title: "version", ValuePath: "version"},
+			{Title: "phase", ValuePath: "phase"},
+			{Title: "subscription", ValuePath: "subscription"},
+			{Title: "channel", ValuePath: "channel"},
+			{Title: "source", ValuePath: "source"},
+			{Title: "approval", ValuePath: "approval", IsWide: true},
+			{Title: "installed csv", ValuePath: "installedCSV", IsWide: true},
+			{Title: "current csv", ValuePath: "currentCSV", IsWide: true},
+		}
+
+		rows := make([]interface{}, 0)
+		for _, operator := range operators {
+			if len(operatorsList) > 0 {
+				_, subscriptionFound := mcUtils.FindItem(operatorsList, operator.Subscription)
+				_, csvFound := mcUtils.FindItem(operatorsList, operator.Name)
+				if !subscriptionFound && !csvFound {
+					continue
+				}
+			}
+			rows = append(rows, operator)
+		}
+
+		printer.Print(os.Stdout, headers, rows, rootCmd.HideHeader, rootCmd.OutputFormat, rootCmd.Pretty)
+	},
+}
@@ -22,6 +22,10 @@ var (
 	installOperatorChannel       string
 	installOperatorEnvironments  []string
 	installOperatorForceRecreate bool
+	installOperatorInstallMode   string
+	installOperatorTargetNS      []string
+	installOperatorStartingCSV   string
+	installOperatorApproval      string
 )
 
 func init() {
@@ -31,6 +35,10 @@ func init() {
 	installOperatorCmd.Flags().StringVar(&installOperatorChannel, "channel", "stable", "channel name of the operator")
 	installOperatorCmd.Flags().StringSliceVar(&installOperatorEnvironments, "environment", []string{}, "comma separated environment variables. key1=value1,key2=value2")
 	installOperatorCmd.Flags().BoolVar(&installOperatorForceRecreate, "force", false, "uninstall the operator if exists and install")
+	installOperatorCmd.Flags().StringVar(&installOperatorInstallMode, "install-mode", "", "install mode of the operator group. options: AllNamespaces, OwnNamespace, SingleNamespace, MultiNamespace. decided from the package, if empty. AllNamespaces preferred on openshift-operators, OwnNamespace on the other namespaces")
+	installOperatorCmd.Flags().StringVar(&installOperatorStartingCSV, "starting-csv", "", "starting csv of the operator, example: my-operator.v1.2.3")
+	installOperatorCmd.Flags().StringVar(&installOperatorApproval, "approval", string(corsosv1alpha1.ApprovalAutomatic), "install plan approval. options: Automatic, Manual. on Manual, the install plan of the starting csv approved")
+	installOperatorCmd.Flags().StringSliceVar(&installOperatorTargetNS, "target-namespaces", []string{}, "comma separated target namespaces of the operator group")
 }
 
 var installOperatorCmd = &cobra.Command{
@@ -38,6 +46,13 @@ var installOperatorCmd = &cobra.Command{
 	Short: "installs operator",
 	Args:  cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, operatorsList []string) {
+		// verify the approval before creating anything
+		approval := corsosv1alpha1.Approval(installOperatorApproval)
+		if approval != corsosv1alpha1.ApprovalAutomatic && approval != corsosv1alpha1.ApprovalManual {
+			zap.L().Error("invalid install plan approval. options: Automatic, Manual", zap.String("approval", installOperatorApproval))
+			rootCmd.ExitWithError()
+		}
+
 		// get kubernetes client
 		k8sClient := openshiftClient.GetKubernetesClient()
 
@@ -83,12 +98,24 @@ var installOperatorCmd = &cobra.Command{
 					CatalogSourceNamespace: "openshift-marketplace",
 					Package:                _operator,
 					Channel:                installOperatorChannel,
+					StartingCSV:            installOperatorStartingCSV,
+					InstallPlanApproval:    approval,
 					Config: &corsosv1alpha1.SubscriptionConfig{
 						Env: _environments,
 					},
 				},
 			}
 
+			ogCfg := types.OperatorGroupConfig{
+				InstallMode:      installOperatorInstallMode,
+				TargetNamespaces: installOperatorTargetNS,
+			}
+			err = operatorAPI.EnsureOperatorGroup(k8sClient, &subscription, ogCfg)
+			if err != nil {
+				zap.L().Error("error on preparing the operator group", zap.String("name", _operator), zap.String("namespace", installOperatorNamespace), zap.Error(err))
+				rootCmd.ExitWithError()
+			}
+
 			tc := types.TimeoutConfig{}
 			tc.UpdateDefaults()
 			tc.ExpectedSuccessCount = 2
@@ -6,13 +6,23 @@ import (
 	operatorAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/operator"
 	subscriptionAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/subscription"
 	openshiftClient "github.com/jkandasa/autoeasy/plugin/provider/openshift/client"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+var (
+	uninstallOperatorConfig types.UninstallConfig
+)
+
 func init() {
 	openshiftUninstallCmd.AddCommand(uninstallOperatorCmd)
+	uninstallOperatorCmd.Flags().BoolVar(&uninstallOperatorConfig.RemoveOperands, "remove-operands", false, "removes the custom resources of the operator, before the operator removal")
+	uninstallOperatorCmd.Flags().BoolVar(&uninstallOperatorConfig.RemoveCRDs, "remove-crds", false, "removes the custom resource definitions owned by the operator")
+	uninstallOperatorCmd.Flags().BoolVar(&uninstallOperatorConfig.RemoveOperatorGroup, "remove-operator-group", false, "removes the operator group, if not used by other operators")
+	uninstallOperatorCmd.Flags().BoolVar(&uninstallOperatorConfig.RemoveInstallPlans, "remove-install-plans", false, "removes the install plans of the operator")
+	uninstallOperatorCmd.Flags().BoolVar(&uninstallOperatorConfig.FullCleanup, "full-cleanup", false, "enables all the remove options")
 }
 
 var uninstallOperatorCmd = &cobra.Command{
@@ -45,7 +55,12 @@ func uninstallOperator(k8sClient client.Client, operatorsList []string) error {
 			if _subscription.Name == _operator {
 				found = true
 				zap.L().Debug("uninstalling an operator", zap.String("operator", _operator))
-				err := operatorAPI.Uninstall(k8sClient, &_subscription)
+				var err error
+				if uninstallOperatorConfig.IsDefined() {
+					err = operatorAPI.UninstallWithCleanup(k8sClient, &_subscription, uninstallOperatorConfig, types.TimeoutConfig{})
+				} else {
+					err = operatorAPI.Uninstall(k8sClient, &_subscription)
+				}
 				if err != nil {
 					zap.L().Error("error on uninstalling an operator", zap.String("operator", _operator), zap.Error(err))
 					continue
@@ -0,0 +1,92 @@
+package operator
+
+import (
+	"os"
+	"time"
+
+	openshiftUpgradeCmd "github.com/jkandasa/autoeasy/cmd/plugin/openshift/upgrade"
+	rootCmd "github.com/jkandasa/autoeasy/cmd/root"
+	operatorAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/operator"
+	openshiftClient "github.com/jkandasa/autoeasy/plugin/provider/openshift/client"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"github.com/mycontroller-org/server/v2/pkg/utils/printer"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	upgradeOperatorNamespace string
+	upgradeOperatorChannel   string
+	upgradeOperatorTargetCSV string
+	upgradeOperatorMaxHops   int
+	upgradeOperatorTimeout   string
+)
+
+func init() {
+	openshiftUpgradeCmd.AddCommand(upgradeOperatorCmd)
+	upgradeOperatorCmd.Flags().StringVar(&upgradeOperatorNamespace, "namespace", "openshift-operators", "namespace of the operator")
+	upgradeOperatorCmd.Flags().StringVar(&upgradeOperatorChannel, "channel", "", "switches to the channel before the upgrade")
+	upgradeOperatorCmd.Flags().StringVar(&upgradeOperatorTargetCSV, "target-csv", "", "stops the upgrade on the csv, upgrades to the latest csv of the channel, if empty")
+	upgradeOperatorCmd.Flags().IntVar(&upgradeOperatorMaxHops, "max-hops", 0, "maximum number of upgrades, 0 for no limit")
+	upgradeOperatorCmd.Flags().StringVar(&upgradeOperatorTimeout, "timeout", "5m", "timeout of each upgrade hop")
+}
+
+var upgradeOperatorCmd = &cobra.Command{
+	Use:   "operator",
+	Short: "upgrades operator through the upgrade graph of the channel",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, operatorsList []string) {
+		// get kubernetes client
+		k8sClient := openshiftClient.GetKubernetesClient()
+
+		tc := types.TimeoutConfig{}
+		tc.UpdateDefaults()
+		tc.ExpectedSuccessCount = 2
+		timeout, err := time.ParseDuration(upgradeOperatorTimeout)
+		if err != nil {
+			zap.L().Error("invalid timeout", zap.String("timeout", upgradeOperatorTimeout), zap.Error(err))
+			rootCmd.ExitWithError()
+		}
+		tc.Timeout = timeout
+
+		rows := make([]interface{}, 0)
+		for _, _operator := range operatorsList {
+			request := types.UpgradeRequest{
+				Name:      _operator,
+				Namespace: upgradeOperatorNamespace,
+				Channel:   upgradeOperatorChannel,
+				TargetCSV: upgradeOperatorTargetCSV,
+				MaxHops:   upgradeOperatorMaxHops,
+			}
+			result, err := operatorAPI.UpgradePath(k8sClient, request, tc)
+			if result != nil {
+				for _, hop := range result.Hops {
+					rows = append(rows, map[string]interface{}{
+						"name":        result.Name,
+						"from":        hop.From,
+						"to":          hop.To,
+						"installPlan": hop.InstallPlan,
+						"timeTaken":   hop.TimeTaken.String(),
+					})
+				}
+			}
+			if err != nil {
+				printHops(rows)
+				zap.L().Error("error on upgrading an operator", zap.String("name", _operator), zap.String("namespace", upgradeOperatorNamespace), zap.Error(err))
+				rootCmd.ExitWithError()
+			}
+		}
+		printHops(rows)
+	},
+}
+
+func printHops(rows []interface{}) {
+	headers := []printer.Header{
+		{Title: "name", ValuePath: "name"},
+		{Title: "from", ValuePath: "from"},
+		{Title: "to", ValuePath: "to"},
+		{Title: "install plan", ValuePath: "installPlan"},
+		{Title: "time taken", ValuePath: "timeTaken"},
+	}
+	printer.Print(os.Stdout, headers, rows, rootCmd.HideHeader, rootCmd.OutputFormat, rootCmd.Pretty)
+}
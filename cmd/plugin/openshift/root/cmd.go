@@ -2,11 +2,14 @@ package openshift
 
 import (
 	rootCmd "github.com/jkandasa/autoeasy/cmd/root"
+	openshiftClient "github.com/jkandasa/autoeasy/plugin/provider/openshift/client"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	rootCmd.AddCommand(openshiftCmd)
+	openshiftCmd.PersistentFlags().StringVar(&openshiftClient.CLIContext, "context", "", "kubeconfig context, default: current context")
+	openshiftCmd.PersistentFlags().StringVar(&openshiftClient.CLICluster, "cluster", "", "kubeconfig cluster, not a named cluster of the plugin config, default: cluster of the context")
 }
 
 var openshiftCmd = &cobra.Command{
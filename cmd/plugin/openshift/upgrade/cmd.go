@@ -0,0 +1,20 @@
+package upgrade
+
+import (
+	openshiftRootCmd "github.com/jkandasa/autoeasy/cmd/plugin/openshift/root"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	openshiftRootCmd.AddCommand(openshiftUpgradeCmd)
+}
+
+var openshiftUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "upgrades a resource",
+}
+
+func AddCommand(cmds ...*cobra.Command) {
+	openshiftUpgradeCmd.AddCommand(cmds...)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/jkandasa/autoeasy/pkg/types"
 	templateUtils "github.com/jkandasa/autoeasy/pkg/utils/template"
 	"github.com/jkandasa/autoeasy/pkg/version"
+	openshiftPlugin "github.com/jkandasa/autoeasy/plugin/provider/openshift"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
@@ -19,6 +20,7 @@ import (
 var (
 	resourceDir  string
 	pluginConfig string
+	autoConfirm  bool
 )
 
 const (
@@ -32,6 +34,7 @@ func init() {
 
 	executeCmd.Flags().StringVar(&resourceDir, "resource-dir", "./resources", "resources directory")
 	executeCmd.Flags().StringVar(&pluginConfig, "plugin-config", "./plugin.yaml", "plugin config file")
+	executeCmd.Flags().BoolVar(&autoConfirm, "yes", false, "confirms the destructive functions on all the openshift tasks")
 }
 
 var executeCmd = &cobra.Command{
@@ -66,6 +69,19 @@ var executeCmd = &cobra.Command{
 			zap.L().Error("error on unmarshal plugin config file", zap.String("plugin-config", pluginConfig), zap.Error(err))
 			ExitWithError()
 		}
+		// update auto confirm on the openshift providers
+		if autoConfirm {
+			for name, providerData := range pluginData.Provider {
+				if providerData.PluginName != openshiftPlugin.PluginName {
+					continue
+				}
+				if providerData.Config == nil {
+					providerData.Config = map[string]interface{}{}
+				}
+				providerData.Config["auto_confirm"] = true
+				pluginData.Provider[name] = providerData
+			}
+		}
 		err = providerSVC.Start(pluginData.Provider)
 		if err != nil {
 			zap.L().Error("error on loading a provider", zap.Error(err))
@@ -98,6 +114,7 @@ var executeCmd = &cobra.Command{
 
 		// execute tasks
 		err = suiteStore.Execute()
+		providerSVC.Close()
 		if err != nil {
 			zap.L().Error("error on execution", zap.Error(err))
 			ExitWithError()
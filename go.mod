@@ -6,7 +6,6 @@ require (
 	github.com/go-cmd/cmd v1.4.1
 	github.com/google/uuid v1.3.0
 	github.com/jaegertracing/jaeger-operator v1.29.1
-	github.com/jkandasa/jenkinsctl v1.1.1
 	github.com/json-iterator/go v1.1.12
 	github.com/mitchellh/mapstructure v1.5.0
 	github.com/mycontroller-org/server/v2 v2.0.0-20221213115822-30de4399f58b
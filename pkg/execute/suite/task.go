@@ -18,12 +18,17 @@ func run(task *templateTY.Task) error {
 		return fmt.Errorf("provider not available. providerName:[%s]", providerName)
 	}
 
+	// notifies the providers after the task, used on the "close_after" of port forwards
+	defer providerSVC.TaskCompleted(task)
+
 	// execute task
 	data, err := provider.Execute(task)
 	if err != nil {
 		zap.L().Error("error on a task", zap.String("taskName", task.Name), zap.String("template", task.Template), zap.Error(err))
 		switch task.OnFailure {
 		case templateTY.OnFailureContinue:
+			// stores the data returned along with the error, example: failed build details
+			storeData(task, data)
 			return nil
 
 		case templateTY.OnFailureExit:
@@ -37,14 +42,16 @@ func run(task *templateTY.Task) error {
 		}
 	}
 
-	if len(task.Store) > 0 {
-		for _, store := range task.Store {
-			if data == nil {
-				dataRepoSVC.Add(store.Key, "")
-			} else {
-				dataRepoSVC.AddWithStore(store, data)
-			}
+	storeData(task, data)
+	return nil
+}
+
+func storeData(task *templateTY.Task, data interface{}) {
+	for _, store := range task.Store {
+		if data == nil {
+			dataRepoSVC.Add(store.Key, "")
+		} else {
+			dataRepoSVC.AddWithStore(store, data)
 		}
 	}
-	return nil
 }
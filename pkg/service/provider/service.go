@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/jkandasa/autoeasy/pkg/types"
+	templateTY "github.com/jkandasa/autoeasy/pkg/types/template"
 	providerPlugin "github.com/jkandasa/autoeasy/plugin/provider"
 	providerPluginTY "github.com/jkandasa/autoeasy/plugin/provider/types"
 	"go.uber.org/zap"
@@ -39,3 +40,22 @@ func GetProvider(name string) providerPluginTY.Plugin {
 	}
 	return nil
 }
+
+// TaskCompleted notifies the providers implemented the task observer
+func TaskCompleted(task *templateTY.Task) {
+	for _, provider := range store {
+		if observer, ok := provider.(providerPluginTY.TaskObserver); ok {
+			observer.TaskCompleted(task)
+		}
+	}
+}
+
+// Close closes all the providers
+func Close() {
+	for providerName, provider := range store {
+		err := provider.Close()
+		if err != nil {
+			zap.L().Error("error on closing a provider", zap.String("providerName", providerName), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MatchPattern verifies the value against the pattern
+// pattern wrapped with "/" is a regular expression, example: "/^test-[0-9]+$/"
+// other patterns are glob, example: "test-*"
+func MatchPattern(pattern, value string) bool {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		matched, err := regexp.MatchString(pattern[1:len(pattern)-1], value)
+		return err == nil && matched
+	}
+	matched, err := path.Match(pattern, value)
+	if err != nil {
+		return pattern == value
+	}
+	return matched
+}
+
+// MatchString returns true, if any of the patterns matches the value
+func MatchString(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if MatchPattern(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchNamespacedName returns true, if any of the patterns matches the target
+// empty namespace on the pattern matches all the namespaces
+func MatchNamespacedName(patterns []types.NamespacedName, target metav1.ObjectMeta) bool {
+	for _, pattern := range patterns {
+		if !MatchPattern(pattern.Name, target.Name) {
+			continue
+		}
+		if pattern.Namespace == "" || MatchPattern(pattern.Namespace, target.Namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToNamespacedNamePatterns converts the items to namespaced name patterns
+// namespace is optional on the patterns
+func ToNamespacedNamePatterns(rawItems []interface{}) []types.NamespacedName {
+	items := make([]types.NamespacedName, 0)
+	for _, rawItem := range rawItems {
+		item, ok := rawItem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, nameFound := item["name"]
+		if !nameFound {
+			continue
+		}
+		namespace := ""
+		if rawNamespace, nsFound := item["namespace"]; nsFound && rawNamespace != nil {
+			namespace = fmt.Sprintf("%v", rawNamespace)
+		}
+		items = append(items, types.NamespacedName{
+			Name:      fmt.Sprintf("%v", name),
+			Namespace: namespace,
+		})
+	}
+	return items
+}
+
+// IsPattern returns true, if the value is a glob or regular expression
+func IsPattern(value string) bool {
+	if len(value) > 1 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		return true
+	}
+	return strings.ContainsAny(value, "*?[")
+}
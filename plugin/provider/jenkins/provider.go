@@ -5,9 +5,6 @@ import (
 	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
 	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
 	providerPluginTY "github.com/jkandasa/autoeasy/plugin/provider/types"
-	jenkins "github.com/jkandasa/jenkinsctl/pkg/jenkins"
-	jenkinsTY "github.com/jkandasa/jenkinsctl/pkg/types"
-	jenkinsCfgTY "github.com/jkandasa/jenkinsctl/pkg/types/config"
 	"go.uber.org/zap"
 )
 
@@ -17,7 +14,7 @@ const (
 
 type Jenkins struct {
 	Config jenkinsProviderTY.PluginConfig
-	Client *jenkins.Client
+	api    *restClient
 }
 
 func New(config map[string]interface{}) (providerPluginTY.Plugin, error) {
@@ -26,6 +23,7 @@ func New(config map[string]interface{}) (providerPluginTY.Plugin, error) {
 	if err != nil {
 		return nil, err
 	}
+	cfg.UpdateDefaults()
 
 	return &Jenkins{Config: cfg}, nil
 }
@@ -36,19 +34,16 @@ func (j *Jenkins) Name() string {
 
 // Start loads jenkins client
 func (j *Jenkins) Start() error {
-	cfg := &jenkinsCfgTY.Config{
-		URL:      j.Config.ServerURL,
-		Insecure: j.Config.Insecure,
-		Username: j.Config.Username,
-		Password: j.Config.Password,
+	client, err := newRestClient(&j.Config)
+	if err != nil {
+		return err
 	}
-	cfg.EncodePassword() // encode the password
-	client, err := jenkins.NewClient(cfg, &jenkinsTY.IOStreams{})
+	version, err := client.Version()
 	if err != nil {
 		return err
 	}
-	j.Client = client
-	zap.L().Debug("jenkins server", zap.Any("version", client.Version()))
+	j.api = client
+	zap.L().Debug("jenkins server", zap.Any("version", version))
 	return nil
 }
 
@@ -62,5 +57,10 @@ func (j *Jenkins) Execute(task *templateTY.Task) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	// update task timeout from the provider config
+	if config.Config.Timeout <= 0 {
+		config.Config.Timeout = j.Config.Timeout
+	}
 	return j.run(config)
 }
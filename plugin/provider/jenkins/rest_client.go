@@ -0,0 +1,511 @@
+package jenkins_provider
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jkandasa/autoeasy/pkg/json"
+	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRequestTimeout = time.Minute * 1
+	headerJenkinsVersion  = "X-Jenkins"
+)
+
+// restClient is used to call the jenkins remote access api
+type restClient struct {
+	serverURL   string
+	username    string
+	password    string
+	bearerToken string
+	httpClient  *http.Client
+	crumbMutex  sync.Mutex
+	crumb       *crumb
+}
+
+// csrf protection crumb
+// empty request field indicates the csrf protection is disabled on the server
+type crumb struct {
+	Value        string `json:"crumb"`
+	RequestField string `json:"crumbRequestField"`
+}
+
+func newRestClient(cfg *jenkinsProviderTY.PluginConfig) (*restClient, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.Insecure} // #nosec G402
+	if cfg.CAFile != "" {
+		caData, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		certPool, err := x509.SystemCertPool()
+		if err != nil {
+			certPool = x509.NewCertPool()
+		}
+		if !certPool.AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("no valid certificates found on the ca file. caFile:%s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	// jenkins crumb is bound to the web session, keep the session cookies
+	cookieJar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// api token is used as password on the basic authentication
+	password := cfg.Password
+	if cfg.APIToken != "" {
+		password = cfg.APIToken
+	}
+
+	return &restClient{
+		serverURL:   strings.TrimSuffix(cfg.ServerURL, "/"),
+		username:    cfg.Username,
+		password:    password,
+		bearerToken: cfg.BearerToken,
+		httpClient:  &http.Client{Transport: transport, Timeout: defaultRequestTimeout, Jar: cookieJar},
+	}, nil
+}
+
+// converts the job name to jenkins job path
+// example: "folder/my-job" to "/job/folder/job/my-job"
+func jobPath(jobName string) string {
+	path := ""
+	for _, name := range strings.Split(strings.Trim(jobName, "/"), "/") {
+		path = fmt.Sprintf("%s/job/%s", path, url.PathEscape(name))
+	}
+	return path
+}
+
+// executes the request and returns the status code and the response body
+// on POST request, includes the crumb and retries once with a new crumb on forbidden status
+func (rc *restClient) do(method, path string, queryParams url.Values, body []byte, headers map[string]string) (int, http.Header, []byte, error) {
+	statusCode, respHeader, respBody, err := rc.doOnce(method, path, queryParams, body, headers)
+	if method == http.MethodPost && statusCode == http.StatusForbidden {
+		zap.L().Debug("forbidden status on post request, retrying with a new crumb", zap.String("path", path))
+		rc.resetCrumb()
+		return rc.doOnce(method, path, queryParams, body, headers)
+	}
+	return statusCode, respHeader, respBody, err
+}
+
+func (rc *restClient) doOnce(method, path string, queryParams url.Values, body []byte, headers map[string]string) (int, http.Header, []byte, error) {
+	reqURL := fmt.Sprintf("%s%s", rc.serverURL, path)
+	if len(queryParams) > 0 {
+		reqURL = fmt.Sprintf("%s?%s", reqURL, queryParams.Encode())
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, reqURL, bodyReader)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	rc.setAuth(req)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	if method == http.MethodPost {
+		_crumb, err := rc.getCrumb()
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		if _crumb != nil && _crumb.RequestField != "" {
+			req.Header.Set(_crumb.RequestField, _crumb.Value)
+		}
+	}
+
+	resp, err := rc.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, resp.Header, nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp.StatusCode, resp.Header, respBody, fmt.Errorf("failed request. method:%s, path:%s, statusCode:%d, status:%s", method, path, resp.StatusCode, resp.Status)
+	}
+	return resp.StatusCode, resp.Header, respBody, nil
+}
+
+func (rc *restClient) setAuth(req *http.Request) {
+	if rc.bearerToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", rc.bearerToken))
+	} else if rc.username != "" {
+		req.SetBasicAuth(rc.username, rc.password)
+	}
+}
+
+// returns the csrf crumb, fetches from the server on the first call
+// returns nil, if the csrf protection is disabled on the server
+func (rc *restClient) getCrumb() (*crumb, error) {
+	rc.crumbMutex.Lock()
+	defer rc.crumbMutex.Unlock()
+
+	if rc.crumb != nil {
+		if rc.crumb.RequestField == "" { // csrf protection disabled
+			return nil, nil
+		}
+		return rc.crumb, nil
+	}
+
+	statusCode, _, respBody, err := rc.doOnce(http.MethodGet, "/crumbIssuer/api/json", nil, nil, nil)
+	if statusCode == http.StatusNotFound {
+		// csrf protection disabled
+		rc.crumb = &crumb{}
+		return nil, nil
+	}
+	if err != nil {
+		zap.L().Error("error on getting crumb", zap.Error(err))
+		return nil, err
+	}
+
+	_crumb := &crumb{}
+	err = json.Unmarshal(respBody, _crumb)
+	if err != nil {
+		return nil, err
+	}
+	rc.crumb = _crumb
+	return rc.crumb, nil
+}
+
+func (rc *restClient) resetCrumb() {
+	rc.crumbMutex.Lock()
+	defer rc.crumbMutex.Unlock()
+	rc.crumb = nil
+}
+
+// executes the GET request and updates the json response into out
+func (rc *restClient) getJSON(path string, queryParams url.Values, out interface{}) error {
+	_, _, respBody, err := rc.do(http.MethodGet, path, queryParams, nil, nil)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// executes the POST request without body
+func (rc *restClient) post(path string, queryParams url.Values) error {
+	_, _, _, err := rc.do(http.MethodPost, path, queryParams, nil, nil)
+	return err
+}
+
+// executes the POST request with xml body
+func (rc *restClient) postXML(path string, queryParams url.Values, data string) error {
+	headers := map[string]string{"Content-Type": "application/xml"}
+	_, _, _, err := rc.do(http.MethodPost, path, queryParams, []byte(data), headers)
+	return err
+}
+
+// splits the job name into parent path and the job short name
+// example: "folder/my-job" to "/job/folder" and "my-job"
+func splitJobName(jobName string) (string, string) {
+	jobName = strings.Trim(jobName, "/")
+	index := strings.LastIndex(jobName, "/")
+	if index == -1 {
+		return "", jobName
+	}
+	return jobPath(jobName[:index]), jobName[index+1:]
+}
+
+// returns the availability of the job
+func (rc *restClient) IsJobExists(jobName string) (bool, error) {
+	statusCode, _, _, err := rc.do(http.MethodGet, fmt.Sprintf("%s/api/json", jobPath(jobName)), url.Values{"tree": []string{"name"}}, nil, nil)
+	if statusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// creates a job with the given config.xml
+func (rc *restClient) CreateJob(jobName, configXML string) error {
+	parentPath, name := splitJobName(jobName)
+	return rc.postXML(fmt.Sprintf("%s/createItem", parentPath), url.Values{"name": []string{name}}, configXML)
+}
+
+// updates the config.xml of the job
+func (rc *restClient) UpdateJob(jobName, configXML string) error {
+	return rc.postXML(fmt.Sprintf("%s/config.xml", jobPath(jobName)), nil, configXML)
+}
+
+// copies a job, source and destination jobs should be on the same folder
+func (rc *restClient) CopyJob(fromJobName, jobName string) error {
+	parentPath, name := splitJobName(jobName)
+	_, fromName := splitJobName(fromJobName)
+	queryParams := url.Values{
+		"name": []string{name},
+		"mode": []string{"copy"},
+		"from": []string{fromName},
+	}
+	return rc.post(fmt.Sprintf("%s/createItem", parentPath), queryParams)
+}
+
+// enables the job
+func (rc *restClient) EnableJob(jobName string) error {
+	return rc.post(fmt.Sprintf("%s/enable", jobPath(jobName)), nil)
+}
+
+// disables the job
+func (rc *restClient) DisableJob(jobName string) error {
+	return rc.post(fmt.Sprintf("%s/disable", jobPath(jobName)), nil)
+}
+
+// deletes the job
+func (rc *restClient) DeleteJob(jobName string) error {
+	return rc.post(fmt.Sprintf("%s/doDelete", jobPath(jobName)), nil)
+}
+
+// returns the jenkins server version
+func (rc *restClient) Version() (string, error) {
+	_, respHeader, _, err := rc.do(http.MethodGet, "/api/json", url.Values{"tree": []string{"mode"}}, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	return respHeader.Get(headerJenkinsVersion), nil
+}
+
+// triggers a build and returns the queue id
+func (rc *restClient) Build(jobName string, parameters map[string]string) (int64, error) {
+	path := fmt.Sprintf("%s/build", jobPath(jobName))
+	var body []byte
+	headers := map[string]string{}
+	if len(parameters) > 0 {
+		path = fmt.Sprintf("%s/buildWithParameters", jobPath(jobName))
+		formData := url.Values{}
+		for key, value := range parameters {
+			formData.Set(key, value)
+		}
+		body = []byte(formData.Encode())
+		headers["Content-Type"] = "application/x-www-form-urlencoded"
+	}
+
+	_, respHeader, _, err := rc.do(http.MethodPost, path, nil, body, headers)
+	if err != nil {
+		return 0, err
+	}
+
+	// location header format: "http://localhost:8080/queue/item/123/"
+	location := strings.TrimSuffix(respHeader.Get("Location"), "/")
+	index := strings.LastIndex(location, "/")
+	if index == -1 {
+		return 0, fmt.Errorf("queue location not available. jobName:%s, location:%s", jobName, location)
+	}
+	return strconv.ParseInt(location[index+1:], 10, 64)
+}
+
+// returns the build of the queue item
+// returns nil, if the build not started yet
+// if the queue item removed from the queue, looks for the build on the given number of the recent builds
+func (rc *restClient) GetBuildByQueueID(jobName string, queueID int64, limit int) (*jenkinsProviderTY.BuildDetail, error) {
+	queueItem := struct {
+		Cancelled  bool `json:"cancelled"`
+		Executable *struct {
+			Number int `json:"number"`
+		} `json:"executable"`
+	}{}
+	path := fmt.Sprintf("/queue/item/%d/api/json", queueID)
+	statusCode, _, respBody, err := rc.do(http.MethodGet, path, nil, nil, nil)
+	if statusCode == http.StatusNotFound {
+		// removed from the queue, look on the recent builds
+		builds := struct {
+			Builds []struct {
+				Number  int   `json:"number"`
+				QueueID int64 `json:"queueId"`
+			} `json:"builds"`
+		}{}
+		queryParams := url.Values{"tree": []string{fmt.Sprintf("builds[number,queueId]{0,%d}", limit)}}
+		err = rc.getJSON(fmt.Sprintf("%s/api/json", jobPath(jobName)), queryParams, &builds)
+		if err != nil {
+			return nil, err
+		}
+		for _, build := range builds.Builds {
+			if build.QueueID == queueID {
+				return rc.GetBuildDetail(jobName, build.Number)
+			}
+		}
+		return nil, fmt.Errorf("build not found for the queue id. jobName:%s, queueId:%d, limit:%d", jobName, queueID, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(respBody, &queueItem)
+	if err != nil {
+		return nil, err
+	}
+	if queueItem.Cancelled {
+		return nil, fmt.Errorf("queue item cancelled. jobName:%s, queueId:%d", jobName, queueID)
+	}
+	if queueItem.Executable == nil {
+		return nil, nil
+	}
+	return rc.GetBuildDetail(jobName, queueItem.Executable.Number)
+}
+
+// returns the last build number of the job
+func (rc *restClient) GetLastBuildNumber(jobName string) (int, error) {
+	build := struct {
+		Number int `json:"number"`
+	}{}
+	err := rc.getJSON(fmt.Sprintf("%s/lastBuild/api/json", jobPath(jobName)), url.Values{"tree": []string{"number"}}, &build)
+	if err != nil {
+		return 0, err
+	}
+	return build.Number, nil
+}
+
+// returns the build details
+func (rc *restClient) GetBuildDetail(jobName string, buildNumber int) (*jenkinsProviderTY.BuildDetail, error) {
+	build := struct {
+		Number    int    `json:"number"`
+		QueueID   int64  `json:"queueId"`
+		Result    string `json:"result"`
+		Building  bool   `json:"building"`
+		URL       string `json:"url"`
+		Duration  int64  `json:"duration"`
+		Timestamp int64  `json:"timestamp"`
+		Actions   []struct {
+			Parameters []struct {
+				Name  string      `json:"name"`
+				Value interface{} `json:"value"`
+			} `json:"parameters"`
+		} `json:"actions"`
+		Culprits []struct {
+			FullName string `json:"fullName"`
+		} `json:"culprits"`
+	}{}
+	queryParams := url.Values{"tree": []string{"number,queueId,result,building,url,duration,timestamp,actions[parameters[name,value]],culprits[fullName]"}}
+	err := rc.getJSON(fmt.Sprintf("%s/%d/api/json", jobPath(jobName), buildNumber), queryParams, &build)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := map[string]interface{}{}
+	for _, action := range build.Actions {
+		for _, parameter := range action.Parameters {
+			parameters[parameter.Name] = parameter.Value
+		}
+	}
+	culprits := []string{}
+	for _, culprit := range build.Culprits {
+		culprits = append(culprits, culprit.FullName)
+	}
+
+	duration := time.Duration(build.Duration) * time.Millisecond
+	return &jenkinsProviderTY.BuildDetail{
+		JobName:        jobName,
+		Number:         build.Number,
+		QueueID:        build.QueueID,
+		Result:         build.Result,
+		Building:       build.Building,
+		URL:            build.URL,
+		Duration:       duration.String(),
+		DurationMillis: build.Duration,
+		Timestamp:      time.UnixMilli(build.Timestamp),
+		Parameters:     parameters,
+		Culprits:       culprits,
+	}, nil
+}
+
+// aborts the running build
+func (rc *restClient) StopBuild(jobName string, buildNumber int) error {
+	return rc.post(fmt.Sprintf("%s/%d/stop", jobPath(jobName), buildNumber), nil)
+}
+
+// cancels the queued item
+func (rc *restClient) CancelQueueItem(queueID int64) error {
+	return rc.post("/queue/cancelItem", url.Values{"id": []string{fmt.Sprintf("%d", queueID)}})
+}
+
+// returns the items available in the build queue
+func (rc *restClient) ListQueue() ([]jenkinsProviderTY.QueueItem, error) {
+	queue := struct {
+		Items []struct {
+			ID           int64  `json:"id"`
+			Why          string `json:"why"`
+			InQueueSince int64  `json:"inQueueSince"`
+			Blocked      bool   `json:"blocked"`
+			Buildable    bool   `json:"buildable"`
+			Stuck        bool   `json:"stuck"`
+			Task         struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"task"`
+		} `json:"items"`
+	}{}
+	err := rc.getJSON("/queue/api/json", nil, &queue)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]jenkinsProviderTY.QueueItem, 0)
+	for _, item := range queue.Items {
+		items = append(items, jenkinsProviderTY.QueueItem{
+			ID:           item.ID,
+			JobName:      jobNameFromURL(rc.serverURL, item.Task.URL, item.Task.Name),
+			JobURL:       item.Task.URL,
+			Why:          item.Why,
+			InQueueSince: item.InQueueSince,
+			Blocked:      item.Blocked,
+			Buildable:    item.Buildable,
+			Stuck:        item.Stuck,
+		})
+	}
+	return items, nil
+}
+
+// converts the job url to full job name
+// example: "http://localhost:8080/job/folder/job/my-job/" to "folder/my-job"
+func jobNameFromURL(serverURL, jobURL, defaultName string) string {
+	path := strings.TrimPrefix(jobURL, serverURL)
+	if path == jobURL && strings.HasPrefix(jobURL, "http") { // different server url, take only path
+		parsedURL, err := url.Parse(jobURL)
+		if err != nil {
+			return defaultName
+		}
+		path = parsedURL.Path
+	}
+	names := []string{}
+	items := strings.Split(strings.Trim(path, "/"), "/")
+	for index := 0; index+1 < len(items); index += 2 {
+		if items[index] != "job" {
+			return defaultName
+		}
+		name, err := url.PathUnescape(items[index+1])
+		if err != nil {
+			return defaultName
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return defaultName
+	}
+	return strings.Join(names, "/")
+}
@@ -0,0 +1,376 @@
+package jenkins_provider
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
+)
+
+const (
+	testCrumbField = "Jenkins-Crumb"
+)
+
+// fakeJenkins is a jenkins stand-in, serves the registered handlers
+// on enabled csrf protection, POST requests without the valid crumb are rejected with forbidden status
+type fakeJenkins struct {
+	server        *httptest.Server
+	mutex         sync.Mutex
+	crumbEnabled  bool
+	crumbValue    string
+	crumbRequests int
+	requests      []string
+	handlers      map[string]http.HandlerFunc
+}
+
+func newFakeJenkins(t *testing.T, crumbEnabled bool) *fakeJenkins {
+	fj := &fakeJenkins{
+		crumbEnabled: crumbEnabled,
+		crumbValue:   "crumb-1",
+		handlers:     map[string]http.HandlerFunc{},
+	}
+	fj.server = httptest.NewServer(fj)
+	t.Cleanup(fj.server.Close)
+	return fj
+}
+
+// handle registers a handler, key format: "METHOD /path"
+func (fj *fakeJenkins) handle(method, path string, handler http.HandlerFunc) {
+	fj.mutex.Lock()
+	defer fj.mutex.Unlock()
+	fj.handlers[fmt.Sprintf("%s %s", method, path)] = handler
+}
+
+func (fj *fakeJenkins) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fj.mutex.Lock()
+	key := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+	fj.requests = append(fj.requests, key)
+	handler := fj.handlers[key]
+	crumbEnabled := fj.crumbEnabled
+	crumbValue := fj.crumbValue
+	if r.URL.Path == "/crumbIssuer/api/json" {
+		fj.crumbRequests++
+	}
+	fj.mutex.Unlock()
+
+	if r.URL.Path == "/crumbIssuer/api/json" {
+		if !crumbEnabled {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"crumb":%q,"crumbRequestField":%q}`, crumbValue, testCrumbField)
+		return
+	}
+
+	if r.Method == http.MethodPost && crumbEnabled && r.Header.Get(testCrumbField) != crumbValue {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if handler == nil {
+		http.NotFound(w, r)
+		return
+	}
+	handler(w, r)
+}
+
+func (fj *fakeJenkins) countRequests(key string) int {
+	fj.mutex.Lock()
+	defer fj.mutex.Unlock()
+	count := 0
+	for _, request := range fj.requests {
+		if request == key {
+			count++
+		}
+	}
+	return count
+}
+
+func (fj *fakeJenkins) client(t *testing.T) *restClient {
+	rc, err := newRestClient(&jenkinsProviderTY.PluginConfig{ServerURL: fj.server.URL, Username: "admin", Password: "admin"})
+	if err != nil {
+		t.Fatalf("error on creating rest client: %v", err)
+	}
+	return rc
+}
+
+func queueLocationHandler(queueID int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", fmt.Sprintf("http://%s/queue/item/%d/", r.Host, queueID))
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func TestPostWithCrumbDisabled(t *testing.T) {
+	fj := newFakeJenkins(t, false)
+	fj.handle(http.MethodPost, "/job/my-job/build", queueLocationHandler(11))
+	rc := fj.client(t)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		queueID, err := rc.Build("my-job", nil)
+		if err != nil {
+			t.Fatalf("attempt:%d, unexpected error: %v", attempt, err)
+		}
+		if queueID != 11 {
+			t.Fatalf("attempt:%d, expected queue id 11, received:%d", attempt, queueID)
+		}
+	}
+
+	if fj.crumbRequests != 1 {
+		t.Errorf("disabled crumb state should be cached, crumb requests:%d", fj.crumbRequests)
+	}
+}
+
+func TestPostWithCrumb(t *testing.T) {
+	fj := newFakeJenkins(t, true)
+	fj.handle(http.MethodPost, "/job/my-job/build", queueLocationHandler(12))
+	rc := fj.client(t)
+
+	_, err := rc.Build("my-job", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// crumb expired on the server, the client should fetch a new crumb and retry once
+	fj.mutex.Lock()
+	fj.crumbValue = "crumb-2"
+	fj.mutex.Unlock()
+
+	queueID, err := rc.Build("my-job", nil)
+	if err != nil {
+		t.Fatalf("unexpected error after crumb rotation: %v", err)
+	}
+	if queueID != 12 {
+		t.Errorf("expected queue id 12, received:%d", queueID)
+	}
+	if fj.crumbRequests != 2 {
+		t.Errorf("expected 2 crumb requests, received:%d", fj.crumbRequests)
+	}
+	if count := fj.countRequests("POST /job/my-job/build"); count != 3 {
+		t.Errorf("expected 3 build requests, received:%d", count)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      jenkinsProviderTY.PluginConfig
+		expected string
+	}{
+		{name: "password", cfg: jenkinsProviderTY.PluginConfig{Username: "admin", Password: "secret"}, expected: "basic:admin:secret"},
+		{name: "api token", cfg: jenkinsProviderTY.PluginConfig{Username: "admin", Password: "secret", APIToken: "token"}, expected: "basic:admin:token"},
+		{name: "bearer token", cfg: jenkinsProviderTY.PluginConfig{Username: "admin", APIToken: "token", BearerToken: "bearer"}, expected: "Bearer bearer"},
+		{name: "anonymous", cfg: jenkinsProviderTY.PluginConfig{}, expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			received := ""
+			fj := newFakeJenkins(t, false)
+			fj.handle(http.MethodGet, "/api/json", func(w http.ResponseWriter, r *http.Request) {
+				if username, password, ok := r.BasicAuth(); ok {
+					received = fmt.Sprintf("basic:%s:%s", username, password)
+				} else {
+					received = r.Header.Get("Authorization")
+				}
+				w.Header().Set(headerJenkinsVersion, "2.387.1")
+				fmt.Fprint(w, `{}`)
+			})
+			test.cfg.ServerURL = fj.server.URL
+			rc, err := newRestClient(&test.cfg)
+			if err != nil {
+				t.Fatalf("error on creating rest client: %v", err)
+			}
+
+			version, err := rc.Version()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != "2.387.1" {
+				t.Errorf("expected version 2.387.1, received:%s", version)
+			}
+			if received != test.expected {
+				t.Errorf("expected authorization %q, received:%q", test.expected, received)
+			}
+		})
+	}
+}
+
+func TestCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerJenkinsVersion, "2.387.1")
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	err := os.WriteFile(caFile, caData, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// without ca file, the server certificate is unknown
+	rc, err := newRestClient(&jenkinsProviderTY.PluginConfig{ServerURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = rc.Version(); err == nil {
+		t.Errorf("expected certificate error without ca file")
+	}
+
+	rc, err = newRestClient(&jenkinsProviderTY.PluginConfig{ServerURL: server.URL, CAFile: caFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = rc.Version(); err != nil {
+		t.Errorf("unexpected error with ca file: %v", err)
+	}
+
+	// invalid ca file
+	invalidFile := filepath.Join(t.TempDir(), "invalid.crt")
+	err = os.WriteFile(invalidFile, []byte("invalid"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = newRestClient(&jenkinsProviderTY.PluginConfig{ServerURL: server.URL, CAFile: invalidFile}); err == nil {
+		t.Errorf("expected error on invalid ca file")
+	}
+}
+
+func TestBuildWithParameters(t *testing.T) {
+	fj := newFakeJenkins(t, true)
+	receivedParameters := url.Values{}
+	fj.handle(http.MethodPost, "/job/folder/job/my-job/buildWithParameters", func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseForm()
+		if err != nil {
+			t.Error(err)
+		}
+		receivedParameters = r.PostForm
+		queueLocationHandler(21)(w, r)
+	})
+	rc := fj.client(t)
+
+	queueID, err := rc.Build("folder/my-job", map[string]string{"VERSION": "1.2.3", "TARGET": "stage"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if queueID != 21 {
+		t.Errorf("expected queue id 21, received:%d", queueID)
+	}
+	if receivedParameters.Get("VERSION") != "1.2.3" || receivedParameters.Get("TARGET") != "stage" {
+		t.Errorf("unexpected parameters: %v", receivedParameters)
+	}
+}
+
+const testBuildJSON = `{
+	"number": 7, "queueId": 31, "result": "FAILURE", "building": false,
+	"url": "http://jenkins/job/my-job/7/", "duration": 65000, "timestamp": 1700000000000,
+	"actions": [{"parameters": [{"name": "VERSION", "value": "1.2.3"}]}, {}],
+	"culprits": [{"fullName": "Jenkins Admin"}]
+}`
+
+func TestGetBuildDetail(t *testing.T) {
+	fj := newFakeJenkins(t, false)
+	fj.handle(http.MethodGet, "/job/my-job/7/api/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testBuildJSON)
+	})
+	rc := fj.client(t)
+
+	detail, err := rc.GetBuildDetail("my-job", 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detail.Number != 7 || detail.QueueID != 31 || detail.Result != jenkinsProviderTY.ResultFailure || detail.Building {
+		t.Errorf("unexpected build detail: %+v", detail)
+	}
+	if detail.Duration != "1m5s" || detail.DurationMillis != 65000 {
+		t.Errorf("unexpected duration: %s, %d", detail.Duration, detail.DurationMillis)
+	}
+	if detail.Parameters["VERSION"] != "1.2.3" {
+		t.Errorf("unexpected parameters: %v", detail.Parameters)
+	}
+	if len(detail.Culprits) != 1 || detail.Culprits[0] != "Jenkins Admin" {
+		t.Errorf("unexpected culprits: %v", detail.Culprits)
+	}
+
+	_, err = rc.GetBuildDetail("my-job", 8)
+	if err == nil {
+		t.Errorf("expected error on unavailable build")
+	}
+}
+
+func TestGetBuildByQueueID(t *testing.T) {
+	fj := newFakeJenkins(t, false)
+	fj.handle(http.MethodGet, "/queue/item/31/api/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"cancelled": false, "executable": {"number": 7}}`)
+	})
+	fj.handle(http.MethodGet, "/queue/item/32/api/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"cancelled": false}`)
+	})
+	fj.handle(http.MethodGet, "/queue/item/33/api/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"cancelled": true}`)
+	})
+	fj.handle(http.MethodGet, "/job/my-job/api/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"builds": [{"number": 8, "queueId": 35}, {"number": 7, "queueId": 34}]}`)
+	})
+	fj.handle(http.MethodGet, "/job/my-job/7/api/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testBuildJSON)
+	})
+	rc := fj.client(t)
+
+	// started build
+	detail, err := rc.GetBuildByQueueID("my-job", 31, 5)
+	if err != nil || detail == nil || detail.Number != 7 {
+		t.Errorf("unexpected result, detail:%+v, error:%v", detail, err)
+	}
+
+	// waiting on the queue
+	detail, err = rc.GetBuildByQueueID("my-job", 32, 5)
+	if err != nil || detail != nil {
+		t.Errorf("expected nil detail on the queued item, detail:%+v, error:%v", detail, err)
+	}
+
+	// cancelled
+	_, err = rc.GetBuildByQueueID("my-job", 33, 5)
+	if err == nil {
+		t.Errorf("expected error on cancelled queue item")
+	}
+
+	// removed from the queue, located from the recent builds
+	detail, err = rc.GetBuildByQueueID("my-job", 34, 5)
+	if err != nil || detail == nil || detail.Number != 7 {
+		t.Errorf("unexpected result from the recent builds, detail:%+v, error:%v", detail, err)
+	}
+
+	// not available on the recent builds
+	_, err = rc.GetBuildByQueueID("my-job", 99, 5)
+	if err == nil {
+		t.Errorf("expected error on unknown queue id")
+	}
+}
+
+func TestJobNameFromURL(t *testing.T) {
+	tests := []struct {
+		jobURL   string
+		expected string
+	}{
+		{jobURL: "http://localhost:8080/job/my-job/", expected: "my-job"},
+		{jobURL: "http://localhost:8080/job/folder/job/my%20job/", expected: "folder/my job"},
+		{jobURL: "http://other-host/job/folder/job/my-job/", expected: "folder/my-job"},
+		{jobURL: "http://localhost:8080/view/all/", expected: "default"},
+	}
+	for _, test := range tests {
+		received := jobNameFromURL("http://localhost:8080", test.jobURL, "default")
+		if received != test.expected {
+			t.Errorf("jobURL:%s, expected:%s, received:%s", test.jobURL, test.expected, received)
+		}
+	}
+}
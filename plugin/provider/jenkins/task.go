@@ -12,6 +12,22 @@ func (j *Jenkins) run(cfg *jenkinsProviderTY.ProviderConfig) (interface{}, error
 	case jenkinsProviderTY.FunctionBuild:
 		return j.build(cfg)
 
+	case jenkinsProviderTY.FunctionWaitForBuild:
+		return j.waitForBuilds(cfg)
+
+	case jenkinsProviderTY.FunctionAbort:
+		return j.abort(cfg)
+
+	case jenkinsProviderTY.FunctionListQueue:
+		return j.listQueue(cfg)
+
+	case jenkinsProviderTY.FunctionCancelQueue:
+		return j.cancelQueue(cfg)
+
+	case jenkinsProviderTY.FunctionCreateJob, jenkinsProviderTY.FunctionUpdateJob, jenkinsProviderTY.FunctionCopyJob,
+		jenkinsProviderTY.FunctionEnableJob, jenkinsProviderTY.FunctionDisableJob, jenkinsProviderTY.FunctionDeleteJob:
+		return nil, j.manageJob(cfg)
+
 	default:
 		return nil, fmt.Errorf("invalid function:%s", cfg.Function)
 	}
@@ -0,0 +1,48 @@
+package jenkins_provider
+
+import (
+	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
+	"go.uber.org/zap"
+)
+
+// aborts running builds or cancels queued items
+// if the build number and the queue id are not supplied, aborts the last build of the job
+func (j *Jenkins) abort(cfg *jenkinsProviderTY.ProviderConfig) (interface{}, error) {
+	// get build data slice
+	buildDataSlice, err := cfg.GetBuildData()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, buildData := range buildDataSlice {
+		// cancel the queued item
+		if buildData.BuildNumber == 0 && buildData.QueueID != 0 {
+			err = j.api.CancelQueueItem(buildData.QueueID)
+			if err != nil {
+				zap.L().Error("error on cancelling a queue item", zap.String("jobName", buildData.JobName), zap.Int64("queueId", buildData.QueueID), zap.Error(err))
+				return nil, err
+			}
+			zap.L().Info("cancelled a queue item", zap.String("jobName", buildData.JobName), zap.Int64("queueId", buildData.QueueID))
+			continue
+		}
+
+		buildNumber := buildData.BuildNumber
+		if buildNumber == 0 {
+			lastBuildNumber, err := j.api.GetLastBuildNumber(buildData.JobName)
+			if err != nil {
+				zap.L().Error("error on getting last build number", zap.String("jobName", buildData.JobName), zap.Error(err))
+				return nil, err
+			}
+			buildNumber = lastBuildNumber
+		}
+
+		err = j.api.StopBuild(buildData.JobName, buildNumber)
+		if err != nil {
+			zap.L().Error("error on aborting a build", zap.String("jobName", buildData.JobName), zap.Int("buildNumber", buildNumber), zap.Error(err))
+			return nil, err
+		}
+		zap.L().Info("aborted a build", zap.String("jobName", buildData.JobName), zap.Int("buildNumber", buildNumber))
+	}
+
+	return nil, nil
+}
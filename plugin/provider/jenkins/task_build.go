@@ -2,15 +2,22 @@ package jenkins_provider
 
 import (
 	"errors"
-	"strings"
+	"fmt"
 	"time"
 
 	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
 	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
-	"github.com/jkandasa/jenkinsctl/pkg/types/jenkins"
 	"go.uber.org/zap"
 )
 
+var (
+	// scan interval of the build status
+	buildScanInterval = time.Second * 10
+
+	ErrMaxRetryReached    = errors.New("reached maximum retry count, no success build")
+	ErrResultNotRetryable = errors.New("build result is not accepted and not retryable")
+)
+
 // executes the build job
 func (j *Jenkins) build(cfg *jenkinsProviderTY.ProviderConfig) (interface{}, error) {
 	// get build data slice
@@ -19,15 +26,25 @@ func (j *Jenkins) build(cfg *jenkinsProviderTY.ProviderConfig) (interface{}, err
 		return nil, err
 	}
 
+	if cfg.Config.Parallel {
+		return j.buildParallel(&cfg.Config, buildDataSlice)
+	}
+
 	responses := make([]interface{}, 0)
 
 	for index := range buildDataSlice {
 		buildData := buildDataSlice[index]
 		response, err := j.buildSingle(&cfg.Config, &buildData)
+		if response != nil {
+			responses = append(responses, response)
+		}
 		if err != nil {
-			return nil, err
+			// returns the completed builds along with the failed build detail
+			if len(buildDataSlice) == 1 && len(responses) == 1 {
+				return responses[0], err
+			}
+			return responses, err
 		}
-		responses = append(responses, response)
 	}
 
 	if len(buildDataSlice) == 1 {
@@ -44,16 +61,14 @@ func (j *Jenkins) buildSingle(taskCfg *jenkinsProviderTY.TaskConfig, buildData *
 		retryCount = taskCfg.RetryCount
 	}
 
-	if buildData.Limit == 0 {
-		buildData.Limit = 5
-	}
-
 	// retry loop
+	attempts := 0
 	for {
 		retryCount--
+		attempts++
 
 		zap.L().Debug("invoking a job", zap.String("jobName", buildData.JobName))
-		queueID, err := j.Client.Build(buildData.JobName, buildData.Parameters)
+		queueID, err := j.api.Build(buildData.JobName, buildData.Parameters)
 		if err != nil {
 			zap.L().Error("error on invoking a job", zap.String("jobName", buildData.JobName), zap.Error(err))
 			return nil, err
@@ -65,64 +80,84 @@ func (j *Jenkins) buildSingle(taskCfg *jenkinsProviderTY.TaskConfig, buildData *
 
 		zap.L().Debug("invoked a job", zap.String("jobName", buildData.JobName), zap.Int64("queueId", queueID))
 
-		buildNumber := int(0)
-		isSuccess := false
-		var response *jenkins.BuildResponse
-
-		// verify the job completion status
-		verifyFunc := func() (bool, error) {
-			if buildNumber == 0 {
-				buildResponse, err := j.Client.GetBuildByQueueID(buildData.JobName, queueID, buildData.Limit)
-				if err != nil {
-					zap.L().Error("error on getting build by queue id", zap.String("jobName", buildData.JobName), zap.Int64("queueId", queueID), zap.Error(err))
-					return false, err
-				}
-				response = buildResponse
-			} else {
-				buildResponse, err := j.Client.GetBuild(buildData.JobName, buildNumber, false)
-				if err != nil {
-					zap.L().Error("error on getting build by build number", zap.String("jobName", buildData.JobName), zap.Int64("queueId", queueID), zap.Int("buildNumber", buildNumber), zap.Error(err))
-					return false, err
-				}
-				response = buildResponse
-			}
+		response, isSuccess, err := j.waitForBuild(taskCfg, buildData, queueID, 0)
+		if err != nil {
+			return nil, err
+		}
 
-			if response == nil {
-				return false, nil
-			}
+		// return immediately, if the job completed successfully
+		if isSuccess {
+			response.Attempts = attempts
+			return response, nil
+		}
 
-			if buildNumber == 0 {
-				buildNumber = int(response.Number)
-			}
+		// returns the last build detail along with the error, can be captured on the store
+		response.Attempts = attempts
+		if !taskCfg.IsRetryableResult(response.Result) {
+			return response, fmt.Errorf("%w. jobName:%s, buildNumber:%d, result:%s, url:%s", ErrResultNotRetryable, buildData.JobName, response.Number, response.Result, response.URL)
+		}
 
-			if response.IsRunning {
-				return false, nil
-			}
+		if retryCount == 0 {
+			return response, fmt.Errorf("%w. jobName:%s, buildNumber:%d, result:%s, url:%s, attempts:%d", ErrMaxRetryReached, buildData.JobName, response.Number, response.Result, response.URL, attempts)
+		}
+		zap.L().Info("retrying a build", zap.String("jobName", buildData.JobName), zap.Int("buildNumber", response.Number), zap.String("result", response.Result), zap.Int("remainingRetries", retryCount))
+	}
+}
 
-			if strings.EqualFold(response.Result, "SUCCESS") {
-				isSuccess = true
-			}
+// waits for the build completion
+// if the build number is not known, build will be located by the queue id
+func (j *Jenkins) waitForBuild(taskCfg *jenkinsProviderTY.TaskConfig, buildData *jenkinsProviderTY.BuildData, queueID int64, buildNumber int) (*jenkinsProviderTY.BuildDetail, bool, error) {
+	if buildData.Limit == 0 {
+		buildData.Limit = 5
+	}
 
-			return true, nil
-		}
+	isSuccess := false
+	var response *jenkinsProviderTY.BuildDetail
 
-		// wait for completion of the job
-		err = funcUtils.ExecuteWithTimeout(verifyFunc, taskCfg.Timeout, time.Second*10)
-		if err != nil {
-			return nil, err
+	// verify the job completion status
+	verifyFunc := func() (bool, error) {
+		if buildNumber == 0 {
+			buildResponse, err := j.api.GetBuildByQueueID(buildData.JobName, queueID, buildData.Limit)
+			if err != nil {
+				zap.L().Error("error on getting build by queue id", zap.String("jobName", buildData.JobName), zap.Int64("queueId", queueID), zap.Error(err))
+				return false, err
+			}
+			response = buildResponse
+		} else {
+			buildResponse, err := j.api.GetBuildDetail(buildData.JobName, buildNumber)
+			if err != nil {
+				zap.L().Error("error on getting build by build number", zap.String("jobName", buildData.JobName), zap.Int64("queueId", queueID), zap.Int("buildNumber", buildNumber), zap.Error(err))
+				return false, err
+			}
+			response = buildResponse
 		}
 
-		if buildNumber != 0 {
-			zap.L().Debug("job status", zap.String("jobName", buildData.JobName), zap.Int("buildNumber", buildNumber), zap.String("result", response.Result), zap.String("timeTaken", response.Duration.String()))
+		if response == nil {
+			return false, nil
 		}
 
-		// return immediately, if the job completed successfully
-		if isSuccess {
-			return response, nil
+		if buildNumber == 0 {
+			buildNumber = response.Number
 		}
 
-		if retryCount == 0 {
-			return nil, errors.New("reached maximum retry count, no success job")
+		if response.Building {
+			return false, nil
 		}
+
+		isSuccess = taskCfg.IsAcceptedResult(response.Result)
+
+		return true, nil
 	}
+
+	// wait for completion of the job
+	err := funcUtils.ExecuteWithTimeout(verifyFunc, taskCfg.Timeout, buildScanInterval)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if buildNumber != 0 {
+		zap.L().Debug("job status", zap.String("jobName", buildData.JobName), zap.Int("buildNumber", buildNumber), zap.String("result", response.Result), zap.String("timeTaken", response.Duration))
+	}
+
+	return response, isSuccess, nil
 }
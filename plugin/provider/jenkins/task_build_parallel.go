@@ -0,0 +1,67 @@
+package jenkins_provider
+
+import (
+	"fmt"
+	"sync"
+
+	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
+	"go.uber.org/zap"
+)
+
+// triggers all the builds and waits for them concurrently
+// returns the result of each build, error is decided by the aggregate policy
+func (j *Jenkins) buildParallel(taskCfg *jenkinsProviderTY.TaskConfig, buildDataSlice []jenkinsProviderTY.BuildData) (interface{}, error) {
+	policy := taskCfg.Policy
+	if policy == "" {
+		policy = jenkinsProviderTY.PolicyAllSuccess
+	}
+	switch policy {
+	case jenkinsProviderTY.PolicyAllSuccess, jenkinsProviderTY.PolicyAnySuccess, jenkinsProviderTY.PolicyContinue:
+	default:
+		return nil, fmt.Errorf("invalid policy:%s", policy)
+	}
+
+	results := make([]jenkinsProviderTY.BuildResult, len(buildDataSlice))
+	wg := sync.WaitGroup{}
+
+	for index := range buildDataSlice {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			buildData := buildDataSlice[index]
+			result := jenkinsProviderTY.BuildResult{JobName: buildData.JobName}
+			response, err := j.buildSingle(taskCfg, &buildData)
+			result.Response = response
+			if err != nil {
+				zap.L().Error("error on a parallel build", zap.String("jobName", buildData.JobName), zap.Error(err))
+				result.Error = err.Error()
+			} else {
+				result.Success = true
+			}
+			results[index] = result
+		}(index)
+	}
+	wg.Wait()
+
+	successCount := 0
+	for _, result := range results {
+		if result.Success {
+			successCount++
+		}
+	}
+	zap.L().Debug("parallel builds completed", zap.String("policy", policy), zap.Int("numberOfBuilds", len(results)), zap.Int("successCount", successCount))
+
+	switch policy {
+	case jenkinsProviderTY.PolicyAllSuccess:
+		if successCount != len(results) {
+			return results, fmt.Errorf("all builds are not successful. policy:%s, success:%d, total:%d", policy, successCount, len(results))
+		}
+
+	case jenkinsProviderTY.PolicyAnySuccess:
+		if successCount == 0 && len(results) > 0 {
+			return results, fmt.Errorf("no build is successful. policy:%s, total:%d", policy, len(results))
+		}
+	}
+
+	return results, nil
+}
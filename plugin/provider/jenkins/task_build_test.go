@@ -0,0 +1,97 @@
+package jenkins_provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
+)
+
+// serves a build for each trigger, builds are completed with the given results in order
+func newBuildJenkins(t *testing.T, results ...string) *fakeJenkins {
+	buildScanInterval = time.Millisecond * 10
+	t.Cleanup(func() { buildScanInterval = time.Second * 10 })
+
+	fj := newFakeJenkins(t, true)
+	var triggered int64
+	fj.handle(http.MethodPost, "/job/my-job/build", func(w http.ResponseWriter, r *http.Request) {
+		queueID := atomic.AddInt64(&triggered, 1)
+		queueLocationHandler(queueID)(w, r)
+	})
+	for index, result := range results {
+		number := index + 1
+		buildResult := result
+		fj.handle(http.MethodGet, fmt.Sprintf("/queue/item/%d/api/json", number), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"executable": {"number": %d}}`, number)
+		})
+		fj.handle(http.MethodGet, fmt.Sprintf("/job/my-job/%d/api/json", number), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"number": %d, "queueId": %d, "result": %q, "url": "http://jenkins/job/my-job/%d/"}`, number, number, buildResult, number)
+		})
+	}
+	return fj
+}
+
+func buildTask(retryCount int, retryOn ...string) *jenkinsProviderTY.ProviderConfig {
+	return &jenkinsProviderTY.ProviderConfig{
+		Function: jenkinsProviderTY.FunctionBuild,
+		Config: jenkinsProviderTY.TaskConfig{
+			WaitForCompletion: true,
+			RetryCount:        retryCount,
+			RetryOn:           retryOn,
+			Timeout:           time.Second * 5,
+		},
+		Data: []interface{}{map[string]interface{}{"job_name": "my-job"}},
+	}
+}
+
+func TestBuildRetrySuccess(t *testing.T) {
+	fj := newBuildJenkins(t, jenkinsProviderTY.ResultFailure, jenkinsProviderTY.ResultSuccess)
+	j := &Jenkins{api: fj.client(t)}
+
+	response, err := j.build(buildTask(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	detail, ok := response.(*jenkinsProviderTY.BuildDetail)
+	if !ok {
+		t.Fatalf("unexpected response type: %T", response)
+	}
+	if detail.Number != 2 || detail.Attempts != 2 || detail.Result != jenkinsProviderTY.ResultSuccess {
+		t.Errorf("unexpected build detail: %+v", detail)
+	}
+}
+
+func TestBuildMaxRetryReached(t *testing.T) {
+	fj := newBuildJenkins(t, jenkinsProviderTY.ResultFailure, jenkinsProviderTY.ResultUnstable)
+	j := &Jenkins{api: fj.client(t)}
+
+	response, err := j.build(buildTask(2))
+	if !errors.Is(err, ErrMaxRetryReached) {
+		t.Fatalf("expected max retry error, received: %v", err)
+	}
+	detail, ok := response.(*jenkinsProviderTY.BuildDetail)
+	if !ok {
+		t.Fatalf("expected the last build detail, received: %T", response)
+	}
+	if detail.Number != 2 || detail.Attempts != 2 || detail.Result != jenkinsProviderTY.ResultUnstable || detail.URL != "http://jenkins/job/my-job/2/" {
+		t.Errorf("unexpected build detail: %+v", detail)
+	}
+}
+
+func TestBuildResultNotRetryable(t *testing.T) {
+	fj := newBuildJenkins(t, jenkinsProviderTY.ResultAborted)
+	j := &Jenkins{api: fj.client(t)}
+
+	response, err := j.build(buildTask(3, jenkinsProviderTY.ResultFailure))
+	if !errors.Is(err, ErrResultNotRetryable) {
+		t.Fatalf("expected not retryable error, received: %v", err)
+	}
+	detail, ok := response.(*jenkinsProviderTY.BuildDetail)
+	if !ok || detail.Result != jenkinsProviderTY.ResultAborted || detail.Attempts != 1 {
+		t.Errorf("unexpected response: %+v", response)
+	}
+}
@@ -0,0 +1,101 @@
+package jenkins_provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	templateUtils "github.com/jkandasa/autoeasy/pkg/utils/template"
+	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
+	"go.uber.org/zap"
+)
+
+// performs job management functions
+func (j *Jenkins) manageJob(cfg *jenkinsProviderTY.ProviderConfig) error {
+	jobDataSlice, err := cfg.GetJobData()
+	if err != nil {
+		return err
+	}
+
+	for _, jobData := range jobDataSlice {
+		if jobData.JobName == "" {
+			return fmt.Errorf("job name can not be empty. function:%s", cfg.Function)
+		}
+
+		switch cfg.Function {
+		case jenkinsProviderTY.FunctionCreateJob:
+			err = j.createOrUpdateJob(&jobData, true)
+
+		case jenkinsProviderTY.FunctionUpdateJob:
+			err = j.createOrUpdateJob(&jobData, false)
+
+		case jenkinsProviderTY.FunctionCopyJob:
+			if jobData.From == "" {
+				return fmt.Errorf("source job name can not be empty. jobName:%s", jobData.JobName)
+			}
+			err = j.api.CopyJob(jobData.From, jobData.JobName)
+
+		case jenkinsProviderTY.FunctionEnableJob:
+			err = j.api.EnableJob(jobData.JobName)
+
+		case jenkinsProviderTY.FunctionDisableJob:
+			err = j.api.DisableJob(jobData.JobName)
+
+		case jenkinsProviderTY.FunctionDeleteJob:
+			err = j.api.DeleteJob(jobData.JobName)
+
+		default:
+			return fmt.Errorf("invalid function:%s", cfg.Function)
+		}
+
+		if err != nil {
+			zap.L().Error("error on a job function", zap.String("function", cfg.Function), zap.String("jobName", jobData.JobName), zap.Error(err))
+			return err
+		}
+		zap.L().Info("job function executed", zap.String("function", cfg.Function), zap.String("jobName", jobData.JobName))
+	}
+	return nil
+}
+
+// creates a job, if not available
+// if the job exists, updates the config.xml
+func (j *Jenkins) createOrUpdateJob(jobData *jenkinsProviderTY.JobData, createIfNotAvailable bool) error {
+	configXML, err := getJobConfigXML(jobData)
+	if err != nil {
+		return err
+	}
+
+	exists, err := j.api.IsJobExists(jobData.JobName)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		zap.L().Debug("job exists, updating the config", zap.String("jobName", jobData.JobName))
+		return j.api.UpdateJob(jobData.JobName, configXML)
+	}
+
+	if !createIfNotAvailable {
+		return fmt.Errorf("job not available. jobName:%s", jobData.JobName)
+	}
+	zap.L().Debug("creating a job", zap.String("jobName", jobData.JobName))
+	return j.api.CreateJob(jobData.JobName, configXML)
+}
+
+// returns the config.xml from inline or from the file, executed with the template engine
+func getJobConfigXML(jobData *jenkinsProviderTY.JobData) (string, error) {
+	rawConfig := jobData.Config
+	if jobData.ConfigFile != "" {
+		data, err := os.ReadFile(jobData.ConfigFile)
+		if err != nil {
+			return "", err
+		}
+		rawConfig = string(data)
+	}
+
+	if rawConfig == "" {
+		return "", errors.New("config and config_file can not be empty")
+	}
+
+	return templateUtils.Execute(rawConfig, jobData.Variables)
+}
@@ -0,0 +1,218 @@
+package jenkins_provider
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
+)
+
+const testJobConfig = `<project><description>{{ .description }}</description></project>`
+
+// returns a handler, stores the received request body
+func bodyHandler(t *testing.T, received *string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		*received = string(data)
+	}
+}
+
+func okHandler(w http.ResponseWriter, r *http.Request) {}
+
+func jobTask(function string, data ...map[string]interface{}) *jenkinsProviderTY.ProviderConfig {
+	cfg := &jenkinsProviderTY.ProviderConfig{Function: function}
+	for _, item := range data {
+		cfg.Data = append(cfg.Data, item)
+	}
+	return cfg
+}
+
+func TestCreateJob(t *testing.T) {
+	fj := newFakeJenkins(t, true)
+	created := ""
+	fj.handle(http.MethodPost, "/job/folder/createItem", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") != "my-job" {
+			t.Errorf("unexpected job name: %s", r.URL.Query().Get("name"))
+		}
+		if r.Header.Get("Content-Type") != "application/xml" {
+			t.Errorf("unexpected content type: %s", r.Header.Get("Content-Type"))
+		}
+		bodyHandler(t, &created)(w, r)
+		fj.handle(http.MethodGet, "/job/folder/job/my-job/api/json", okHandler)
+	})
+	updated := ""
+	fj.handle(http.MethodPost, "/job/folder/job/my-job/config.xml", bodyHandler(t, &updated))
+	j := &Jenkins{api: fj.client(t)}
+
+	// creates the job, not available
+	cfg := jobTask(jenkinsProviderTY.FunctionCreateJob, map[string]interface{}{
+		"job_name":  "folder/my-job",
+		"config":    testJobConfig,
+		"variables": map[string]interface{}{"description": "created"},
+	})
+	err := j.manageJob(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created != "<project><description>created</description></project>" {
+		t.Errorf("unexpected config on create: %s", created)
+	}
+
+	// updates the config, available
+	cfg.Data[0].(map[string]interface{})["variables"] = map[string]interface{}{"description": "updated"}
+	err = j.manageJob(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated != "<project><description>updated</description></project>" {
+		t.Errorf("unexpected config on update: %s", updated)
+	}
+	if count := fj.countRequests("POST /job/folder/createItem"); count != 1 {
+		t.Errorf("expected a create request, received:%d", count)
+	}
+}
+
+func TestUpdateJob(t *testing.T) {
+	fj := newFakeJenkins(t, false)
+	updated := ""
+	fj.handle(http.MethodGet, "/job/my-job/api/json", okHandler)
+	fj.handle(http.MethodPost, "/job/my-job/config.xml", bodyHandler(t, &updated))
+	j := &Jenkins{api: fj.client(t)}
+
+	configFile := filepath.Join(t.TempDir(), "config.xml")
+	err := os.WriteFile(configFile, []byte(testJobConfig), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = j.manageJob(jobTask(jenkinsProviderTY.FunctionUpdateJob, map[string]interface{}{
+		"job_name":    "my-job",
+		"config_file": configFile,
+		"variables":   map[string]interface{}{"description": "from file"},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated != "<project><description>from file</description></project>" {
+		t.Errorf("unexpected config on update: %s", updated)
+	}
+
+	// update does not create the job
+	err = j.manageJob(jobTask(jenkinsProviderTY.FunctionUpdateJob, map[string]interface{}{
+		"job_name": "other-job",
+		"config":   testJobConfig,
+	}))
+	if err == nil {
+		t.Errorf("expected error on unavailable job")
+	}
+
+	// config is required
+	err = j.manageJob(jobTask(jenkinsProviderTY.FunctionUpdateJob, map[string]interface{}{"job_name": "my-job"}))
+	if err == nil {
+		t.Errorf("expected error on empty config")
+	}
+}
+
+func TestCopyJob(t *testing.T) {
+	fj := newFakeJenkins(t, true)
+	fj.handle(http.MethodPost, "/job/folder/createItem", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("name") != "new-job" || query.Get("mode") != "copy" || query.Get("from") != "my-job" {
+			t.Errorf("unexpected copy query: %v", query)
+		}
+	})
+	j := &Jenkins{api: fj.client(t)}
+
+	err := j.manageJob(jobTask(jenkinsProviderTY.FunctionCopyJob, map[string]interface{}{"job_name": "folder/new-job", "from": "folder/my-job"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// source job is required
+	err = j.manageJob(jobTask(jenkinsProviderTY.FunctionCopyJob, map[string]interface{}{"job_name": "folder/new-job"}))
+	if err == nil {
+		t.Errorf("expected error on empty source job")
+	}
+}
+
+func TestEnableDisableDeleteJob(t *testing.T) {
+	tests := []struct {
+		function string
+		path     string
+	}{
+		{function: jenkinsProviderTY.FunctionEnableJob, path: "/job/folder/job/my-job/enable"},
+		{function: jenkinsProviderTY.FunctionDisableJob, path: "/job/folder/job/my-job/disable"},
+		{function: jenkinsProviderTY.FunctionDeleteJob, path: "/job/folder/job/my-job/doDelete"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.function, func(t *testing.T) {
+			fj := newFakeJenkins(t, true)
+			fj.handle(http.MethodPost, test.path, okHandler)
+			j := &Jenkins{api: fj.client(t)}
+
+			err := j.manageJob(jobTask(test.function, map[string]interface{}{"job_name": "folder/my-job"}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if count := fj.countRequests("POST " + test.path); count != 1 {
+				t.Errorf("expected a request, received:%d", count)
+			}
+
+			// unavailable job
+			err = j.manageJob(jobTask(test.function, map[string]interface{}{"job_name": "folder/other-job"}))
+			if err == nil {
+				t.Errorf("expected error on unavailable job")
+			}
+		})
+	}
+}
+
+func TestJobFunctionCrumbRetry(t *testing.T) {
+	fj := newFakeJenkins(t, true)
+	fj.handle(http.MethodPost, "/job/my-job/disable", okHandler)
+	j := &Jenkins{api: fj.client(t)}
+
+	err := j.manageJob(jobTask(jenkinsProviderTY.FunctionDisableJob, map[string]interface{}{"job_name": "my-job"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// crumb expired on the server, retried once with a new crumb
+	fj.mutex.Lock()
+	fj.crumbValue = "crumb-2"
+	fj.mutex.Unlock()
+	err = j.manageJob(jobTask(jenkinsProviderTY.FunctionDisableJob, map[string]interface{}{"job_name": "my-job"}))
+	if err != nil {
+		t.Fatalf("unexpected error after crumb rotation: %v", err)
+	}
+	if count := fj.countRequests("POST /job/my-job/disable"); count != 3 {
+		t.Errorf("expected 3 disable requests, received:%d", count)
+	}
+
+	// forbidden on the retry too, returns the error
+	fj.handle(http.MethodPost, "/job/my-job/enable", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	err = j.manageJob(jobTask(jenkinsProviderTY.FunctionEnableJob, map[string]interface{}{"job_name": "my-job"}))
+	if err == nil {
+		t.Errorf("expected error on forbidden status")
+	}
+	if count := fj.countRequests("POST /job/my-job/enable"); count != 2 {
+		t.Errorf("expected 2 enable requests, received:%d", count)
+	}
+}
+
+func TestJobFunctionValidation(t *testing.T) {
+	j := &Jenkins{}
+	err := j.manageJob(jobTask(jenkinsProviderTY.FunctionDeleteJob, map[string]interface{}{"job_name": ""}))
+	if err == nil {
+		t.Errorf("expected error on empty job name")
+	}
+}
@@ -0,0 +1,83 @@
+package jenkins_provider
+
+import (
+	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
+	"go.uber.org/zap"
+)
+
+// returns the build queue items
+// if job names supplied, returns only the items of those jobs
+func (j *Jenkins) listQueue(cfg *jenkinsProviderTY.ProviderConfig) (interface{}, error) {
+	// get build data slice
+	buildDataSlice, err := cfg.GetBuildData()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := j.api.ListQueue()
+	if err != nil {
+		zap.L().Error("error on getting queue items", zap.Error(err))
+		return nil, err
+	}
+
+	if len(buildDataSlice) == 0 {
+		return items, nil
+	}
+
+	filteredItems := make([]jenkinsProviderTY.QueueItem, 0)
+	for _, item := range items {
+		for _, buildData := range buildDataSlice {
+			if item.JobName == buildData.JobName {
+				filteredItems = append(filteredItems, item)
+				break
+			}
+		}
+	}
+	return filteredItems, nil
+}
+
+// cancels the queued items
+// cancels the given queue id, if not supplied cancels all the queued items of the job
+func (j *Jenkins) cancelQueue(cfg *jenkinsProviderTY.ProviderConfig) (interface{}, error) {
+	// get build data slice
+	buildDataSlice, err := cfg.GetBuildData()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []jenkinsProviderTY.QueueItem
+	cancelledIDs := make([]int64, 0)
+
+	for _, buildData := range buildDataSlice {
+		queueIDs := []int64{}
+		if buildData.QueueID != 0 {
+			queueIDs = append(queueIDs, buildData.QueueID)
+		} else {
+			// load queue items, only once
+			if items == nil {
+				items, err = j.api.ListQueue()
+				if err != nil {
+					zap.L().Error("error on getting queue items", zap.Error(err))
+					return nil, err
+				}
+			}
+			for _, item := range items {
+				if item.JobName == buildData.JobName {
+					queueIDs = append(queueIDs, item.ID)
+				}
+			}
+		}
+
+		for _, queueID := range queueIDs {
+			err = j.api.CancelQueueItem(queueID)
+			if err != nil {
+				zap.L().Error("error on cancelling a queue item", zap.String("jobName", buildData.JobName), zap.Int64("queueId", queueID), zap.Error(err))
+				return nil, err
+			}
+			zap.L().Info("cancelled a queue item", zap.String("jobName", buildData.JobName), zap.Int64("queueId", queueID))
+			cancelledIDs = append(cancelledIDs, queueID)
+		}
+	}
+
+	return cancelledIDs, nil
+}
@@ -0,0 +1,59 @@
+package jenkins_provider
+
+import (
+	"fmt"
+
+	jenkinsProviderTY "github.com/jkandasa/autoeasy/plugin/provider/jenkins/types"
+	"go.uber.org/zap"
+)
+
+// waits for the already triggered builds, will not trigger a new build
+func (j *Jenkins) waitForBuilds(cfg *jenkinsProviderTY.ProviderConfig) (interface{}, error) {
+	// get build data slice
+	buildDataSlice, err := cfg.GetBuildData()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]interface{}, 0)
+
+	for index := range buildDataSlice {
+		buildData := buildDataSlice[index]
+		response, err := j.waitForExistingBuild(&cfg.Config, &buildData)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+
+	if len(buildDataSlice) == 1 {
+		return responses[0], nil
+	}
+	return responses, nil
+}
+
+// waits for a build
+// build is located in the following order, build number, queue id and the last build of the job
+func (j *Jenkins) waitForExistingBuild(taskCfg *jenkinsProviderTY.TaskConfig, buildData *jenkinsProviderTY.BuildData) (interface{}, error) {
+	buildNumber := buildData.BuildNumber
+	if buildNumber == 0 && buildData.QueueID == 0 {
+		lastBuildNumber, err := j.api.GetLastBuildNumber(buildData.JobName)
+		if err != nil {
+			zap.L().Error("error on getting last build number", zap.String("jobName", buildData.JobName), zap.Error(err))
+			return nil, err
+		}
+		buildNumber = lastBuildNumber
+	}
+
+	zap.L().Debug("waiting for a build", zap.String("jobName", buildData.JobName), zap.Int("buildNumber", buildNumber), zap.Int64("queueId", buildData.QueueID))
+	response, isSuccess, err := j.waitForBuild(taskCfg, buildData, buildData.QueueID, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSuccess {
+		return nil, fmt.Errorf("build result is not accepted. jobName:%s, buildNumber:%d, result:%s", buildData.JobName, response.Number, response.Result)
+	}
+	response.Attempts = 1
+	return response, nil
+}
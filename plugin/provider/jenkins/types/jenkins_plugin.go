@@ -2,11 +2,25 @@ package jenkins_provider
 
 import "time"
 
+const (
+	DefaultTimeout = time.Minute * 30
+)
+
 // plugin configuration
+// authentication preference: bearer token, api token and password
 type PluginConfig struct {
-	ServerURL string        `yaml:"server_url"`
-	Insecure  bool          `yaml:"insecure"`
-	Username  string        `yaml:"username"`
-	Password  string        `yaml:"password"`
-	Timeout   time.Duration `yaml:"timeout"`
+	ServerURL   string        `yaml:"server_url"`
+	Insecure    bool          `yaml:"insecure"`
+	Username    string        `yaml:"username"`
+	Password    string        `yaml:"password"`
+	APIToken    string        `yaml:"api_token"`
+	BearerToken string        `yaml:"bearer_token"`
+	CAFile      string        `yaml:"ca_file"`
+	Timeout     time.Duration `yaml:"timeout"`
+}
+
+func (pc *PluginConfig) UpdateDefaults() {
+	if pc.Timeout <= 0 {
+		pc.Timeout = DefaultTimeout
+	}
 }
@@ -1,14 +1,41 @@
 package jenkins_provider
 
 import (
+	"strings"
 	"time"
 
 	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
 )
 
 const (
-	FunctionBuild   = "build"
-	FunctionRebuild = "rebuild"
+	FunctionBuild        = "build"
+	FunctionRebuild      = "rebuild"
+	FunctionAbort        = "abort"
+	FunctionWaitForBuild = "wait_for_build"
+	FunctionListQueue    = "list_queue"
+	FunctionCancelQueue  = "cancel_queue"
+	FunctionCreateJob    = "create_job"
+	FunctionUpdateJob    = "update_job"
+	FunctionCopyJob      = "copy_job"
+	FunctionEnableJob    = "enable_job"
+	FunctionDisableJob   = "disable_job"
+	FunctionDeleteJob    = "delete_job"
+)
+
+// build results
+const (
+	ResultSuccess  = "SUCCESS"
+	ResultUnstable = "UNSTABLE"
+	ResultFailure  = "FAILURE"
+	ResultAborted  = "ABORTED"
+	ResultNotBuilt = "NOT_BUILT"
+)
+
+// parallel build aggregate policies
+const (
+	PolicyAllSuccess = "all_success"
+	PolicyAnySuccess = "any_success"
+	PolicyContinue   = "continue"
 )
 
 // Provider configuration
@@ -22,6 +49,37 @@ type TaskConfig struct {
 	WaitForCompletion bool          `yaml:"wait_for_completion"`
 	RetryCount        int           `yaml:"retry_count"`
 	Timeout           time.Duration `yaml:"timeout"`
+	Parallel          bool          `yaml:"parallel"`
+	Policy            string        `yaml:"policy"`
+	AcceptedResults   []string      `yaml:"accepted_results"`
+	RetryOn           []string      `yaml:"retry_on"`
+}
+
+// IsAcceptedResult returns true, if the build result is on the accepted results
+// if accepted results not defined, only "SUCCESS" is accepted
+func (tc *TaskConfig) IsAcceptedResult(result string) bool {
+	if len(tc.AcceptedResults) == 0 {
+		return strings.EqualFold(result, ResultSuccess)
+	}
+	return containsResult(tc.AcceptedResults, result)
+}
+
+// IsRetryableResult returns true, if the build can be retried for this result
+// if retry on results not defined, all the results are retryable
+func (tc *TaskConfig) IsRetryableResult(result string) bool {
+	if len(tc.RetryOn) == 0 {
+		return true
+	}
+	return containsResult(tc.RetryOn, result)
+}
+
+func containsResult(results []string, result string) bool {
+	for _, _result := range results {
+		if strings.EqualFold(_result, result) {
+			return true
+		}
+	}
+	return false
 }
 
 // converts the data to build data
@@ -36,7 +94,65 @@ func (p *ProviderConfig) GetBuildData() ([]BuildData, error) {
 
 // build data
 type BuildData struct {
-	JobName    string            `yaml:"job_name"`
-	Limit      int               `yaml:"limit"`
-	Parameters map[string]string `yaml:"parameters"`
+	JobName     string            `yaml:"job_name"`
+	Limit       int               `yaml:"limit"`
+	Parameters  map[string]string `yaml:"parameters"`
+	BuildNumber int               `yaml:"build_number"`
+	QueueID     int64             `yaml:"queue_id"`
+}
+
+// converts the data to job data
+func (p *ProviderConfig) GetJobData() ([]JobData, error) {
+	jobData := make([]JobData, 0)
+	err := formatterUtils.YamlInterfaceToStruct(p.Data, &jobData)
+	if err != nil {
+		return nil, err
+	}
+	return jobData, nil
+}
+
+// job data
+// config.xml can be supplied inline or from a file and it will be executed as a template with the variables
+type JobData struct {
+	JobName    string                 `yaml:"job_name"`
+	From       string                 `yaml:"from"`
+	Config     string                 `yaml:"config"`
+	ConfigFile string                 `yaml:"config_file"`
+	Variables  map[string]interface{} `yaml:"variables"`
+}
+
+// result of a build on parallel execution
+type BuildResult struct {
+	JobName  string      `json:"jobName" yaml:"jobName"`
+	Success  bool        `json:"success" yaml:"success"`
+	Error    string      `json:"error" yaml:"error"`
+	Response interface{} `json:"response" yaml:"response"`
+}
+
+// build details
+type BuildDetail struct {
+	JobName        string                 `json:"jobName" yaml:"jobName"`
+	Number         int                    `json:"number" yaml:"number"`
+	QueueID        int64                  `json:"queueId" yaml:"queueId"`
+	Result         string                 `json:"result" yaml:"result"`
+	Building       bool                   `json:"building" yaml:"building"`
+	URL            string                 `json:"url" yaml:"url"`
+	Duration       string                 `json:"duration" yaml:"duration"`
+	DurationMillis int64                  `json:"durationMillis" yaml:"durationMillis"`
+	Timestamp      time.Time              `json:"timestamp" yaml:"timestamp"`
+	Parameters     map[string]interface{} `json:"parameters" yaml:"parameters"`
+	Culprits       []string               `json:"culprits" yaml:"culprits"`
+	Attempts       int                    `json:"attempts" yaml:"attempts"`
+}
+
+// queue item details
+type QueueItem struct {
+	ID           int64  `json:"id" yaml:"id"`
+	JobName      string `json:"jobName" yaml:"jobName"`
+	JobURL       string `json:"jobUrl" yaml:"jobUrl"`
+	Why          string `json:"why" yaml:"why"`
+	InQueueSince int64  `json:"inQueueSince" yaml:"inQueueSince"`
+	Blocked      bool   `json:"blocked" yaml:"blocked"`
+	Buildable    bool   `json:"buildable" yaml:"buildable"`
+	Stuck        bool   `json:"stuck" yaml:"stuck"`
 }
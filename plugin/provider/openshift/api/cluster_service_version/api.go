@@ -58,10 +58,11 @@ func CreateWithMap(k8sClient client.Client, cfg map[string]interface{}) error {
 	return k8sClient.Create(context.Background(), csv)
 }
 
-func Info(k8sClient client.Client) ([]openshiftTY.Info, error) {
+// Info returns the installed operators of the namespace with the subscription details, all the namespaces, if empty
+// copied csvs of the operators watching the other namespaces are excluded
+func Info(k8sClient client.Client, namespace string) ([]openshiftTY.Info, error) {
 	opts := []client.ListOption{
-		// check only rom default namespace, otherwise we will duplicate in all namespaces
-		client.InNamespace("default"),
+		client.InNamespace(namespace),
 	}
 
 	csvList, err := List(k8sClient, opts)
@@ -69,21 +70,51 @@ func Info(k8sClient client.Client) ([]openshiftTY.Info, error) {
 		return nil, err
 	}
 
-	fmt.Println("items count:", len(csvList.Items))
+	subscriptionList := &corsosv1alpha1.SubscriptionList{}
+	err = k8sClient.List(context.Background(), subscriptionList, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// map the subscriptions with installed and current csv
+	subscriptions := map[string]*corsosv1alpha1.Subscription{}
+	for index := range subscriptionList.Items {
+		subscription := &subscriptionList.Items[index]
+		for _, csvName := range []string{subscription.Status.CurrentCSV, subscription.Status.InstalledCSV} {
+			if csvName != "" {
+				subscriptions[fmt.Sprintf("%s/%s", subscription.Namespace, csvName)] = subscription
+			}
+		}
+	}
 
 	csvs := make([]openshiftTY.Info, 0)
 	for _, csv := range csvList.Items {
+		if csv.IsCopied() {
+			continue
+		}
 		images := []string{}
 		for _, img := range csv.Spec.RelatedImages {
 			images = append(images, img.Image)
 		}
-		csvs = append(csvs, openshiftTY.Info{
+		info := openshiftTY.Info{
+			Namespace:   csv.Namespace,
 			Name:        csv.Name,
 			DisplayName: csv.Spec.DisplayName,
 			Version:     csv.Spec.Version,
 			Phase:       csv.Status.Phase,
 			Images:      images,
-		})
+		}
+		if subscription, found := subscriptions[fmt.Sprintf("%s/%s", csv.Namespace, csv.Name)]; found {
+			info.Subscription = subscription.Name
+			info.InstalledCSV = subscription.Status.InstalledCSV
+			info.CurrentCSV = subscription.Status.CurrentCSV
+			if subscription.Spec != nil {
+				info.Channel = subscription.Spec.Channel
+				info.Source = subscription.Spec.CatalogSource
+				info.Approval = subscription.Spec.InstallPlanApproval
+			}
+		}
+		csvs = append(csvs, info)
 	}
 
 	return csvs, nil
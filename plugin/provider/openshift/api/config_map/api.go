@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func List(k8sClient client.Client, opts []client.ListOption) (*corev1.ConfigMapList, error) {
+	configMapList := &corev1.ConfigMapList{}
+	err := k8sClient.List(context.Background(), configMapList, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return configMapList, nil
+}
+
+func Get(k8sClient client.Client, name, namespace string) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{}
+	namespacedName := types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}
+	err := k8sClient.Get(context.Background(), namespacedName, configMap)
+	if err != nil {
+		return nil, err
+	}
+	return configMap, nil
+}
+
+func Delete(k8sClient client.Client, configMap *corev1.ConfigMap) error {
+	return utils.IgnoreNotFoundError(k8sClient.Delete(context.Background(), configMap))
+}
+
+func DeleteOfAll(k8sClient client.Client, configMap *corev1.ConfigMap, opts []client.DeleteAllOfOption) error {
+	if configMap == nil {
+		configMap = &corev1.ConfigMap{}
+	}
+	return k8sClient.DeleteAllOf(context.Background(), configMap, opts...)
+}
+
+func Create(k8sClient client.Client, configMap *corev1.ConfigMap) error {
+	return k8sClient.Create(context.Background(), configMap)
+}
+
+func Update(k8sClient client.Client, configMap *corev1.ConfigMap) error {
+	return k8sClient.Update(context.Background(), configMap)
+}
+
+func CreateWithMap(k8sClient client.Client, cfg map[string]interface{}) error {
+	configMap := &corev1.ConfigMap{}
+	err := formatterUtils.JsonMapToStruct(cfg, configMap)
+	if err != nil {
+		return err
+	}
+	return k8sClient.Create(context.Background(), configMap)
+}
+
+// New returns a ConfigMap from the config data
+// non UTF-8 values are stored as binary data
+func New(cfg openshiftTY.ConfigData) (*corev1.ConfigMap, error) {
+	data, err := BuildData(cfg)
+	if err != nil {
+		return nil, err
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cfg.Name,
+			Namespace:   cfg.Namespace,
+			Labels:      cfg.Labels,
+			Annotations: cfg.Annotations,
+		},
+		Data:       map[string]string{},
+		BinaryData: map[string][]byte{},
+	}
+	for key, value := range data {
+		if utf8.Valid(value) {
+			configMap.Data[key] = string(value)
+		} else {
+			configMap.BinaryData[key] = value
+		}
+	}
+	return configMap, nil
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	fileUtils "github.com/jkandasa/autoeasy/pkg/utils/file"
+	templateUtils "github.com/jkandasa/autoeasy/pkg/utils/template"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+)
+
+// BuildData returns the data from literals, files, directories and templates
+// duplicate keys are not allowed
+func BuildData(cfg openshiftTY.ConfigData) (map[string][]byte, error) {
+	data := map[string][]byte{}
+	addData := func(key string, value []byte) error {
+		if _, found := data[key]; found {
+			return fmt.Errorf("duplicate key:%s, name:%s, namespace:%s", key, cfg.Name, cfg.Namespace)
+		}
+		data[key] = value
+		return nil
+	}
+
+	for key, value := range cfg.Literals {
+		err := addData(key, []byte(value))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// file format: "key=path" or "path", on "path" the file name used as a key
+	for _, file := range cfg.Files {
+		key, filePath := filepath.Base(file), file
+		if index := strings.Index(file, "="); index != -1 {
+			key, filePath = file[:index], file[index+1:]
+		}
+		value, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		err = addData(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// regular files of the directory, sub directories are ignored
+	for _, dir := range cfg.Directories {
+		if !fileUtils.IsDirExists(dir) {
+			return nil, fmt.Errorf("directory not found:%s", dir)
+		}
+		files, err := fileUtils.ListFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			value, err := os.ReadFile(file.FullPath)
+			if err != nil {
+				return nil, err
+			}
+			err = addData(file.Name, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, tpl := range cfg.Templates {
+		templateString := tpl.Template
+		if tpl.File != "" {
+			value, err := os.ReadFile(tpl.File)
+			if err != nil {
+				return nil, err
+			}
+			templateString = string(value)
+		}
+		key := tpl.Key
+		if key == "" && tpl.File != "" {
+			key = filepath.Base(tpl.File)
+		}
+		if key == "" {
+			return nil, fmt.Errorf("template key can not be empty. name:%s, namespace:%s", cfg.Name, cfg.Namespace)
+		}
+		rendered, err := templateUtils.Execute(templateString, tpl.Variables)
+		if err != nil {
+			return nil, err
+		}
+		err = addData(key, []byte(rendered))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func List(k8sClient client.Client, opts []client.ListOption) (*batchv1.CronJobList, error) {
+	cronJobList := &batchv1.CronJobList{}
+	err := k8sClient.List(context.Background(), cronJobList, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return cronJobList, nil
+}
+
+func Get(k8sClient client.Client, name, namespace string) (*batchv1.CronJob, error) {
+	cronJob := &batchv1.CronJob{}
+	namespacedName := types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}
+	err := k8sClient.Get(context.Background(), namespacedName, cronJob)
+	if err != nil {
+		return nil, err
+	}
+	return cronJob, nil
+}
+
+// deletes the CronJob with the jobs
+func Delete(k8sClient client.Client, cronJob *batchv1.CronJob) error {
+	return utils.IgnoreNotFoundError(k8sClient.Delete(context.Background(), cronJob, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+}
+
+func DeleteOfAll(k8sClient client.Client, cronJob *batchv1.CronJob, opts []client.DeleteAllOfOption) error {
+	if cronJob == nil {
+		cronJob = &batchv1.CronJob{}
+	}
+	return k8sClient.DeleteAllOf(context.Background(), cronJob, opts...)
+}
+
+func Create(k8sClient client.Client, cronJob *batchv1.CronJob) error {
+	return k8sClient.Create(context.Background(), cronJob)
+}
+
+func CreateWithMap(k8sClient client.Client, cfg map[string]interface{}) error {
+	cronJob := &batchv1.CronJob{}
+	err := formatterUtils.JsonMapToStruct(cfg, cronJob)
+	if err != nil {
+		return err
+	}
+	return k8sClient.Create(context.Background(), cronJob)
+}
+
+// Trigger creates a Job from the CronJob template, same as "oc create job --from=cronjob/<name>"
+func Trigger(k8sClient client.Client, name, namespace, jobName string) (*batchv1.Job, error) {
+	cronJob, err := Get(k8sClient, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if jobName == "" {
+		jobName = fmt.Sprintf("%s-manual-%d", cronJob.Name, time.Now().Unix())
+	}
+
+	annotations := map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+	for key, value := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[key] = value
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        jobName,
+			Namespace:   cronJob.Namespace,
+			Labels:      cronJob.Spec.JobTemplate.Labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+	err = k8sClient.Create(context.Background(), job)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
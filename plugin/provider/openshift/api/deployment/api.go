@@ -9,10 +9,10 @@ import (
 
 	"github.com/jkandasa/autoeasy/pkg/utils"
 	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
-	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
 	podAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/pod"
 
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/watch"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -85,43 +85,36 @@ func CreateWithMap(k8sClient client.Client, cfg map[string]interface{}) error {
 	return k8sClient.Create(context.Background(), deployment)
 }
 
-// wait for deployment
+// wait for deployments, watches the deployments and reacts on the changes
 func WaitForDeployments(k8sClient client.Client, deployments []string, namespace string, tc openshiftTY.TimeoutConfig) error {
-	executeFunc := func() (bool, error) {
-		return isDeployed(k8sClient, deployments, namespace)
+	targets := make([]types.NamespacedName, len(deployments))
+	for index, name := range deployments {
+		targets[index] = types.NamespacedName{Name: name, Namespace: namespace}
 	}
-	return funcUtils.ExecuteWithTimeoutAndContinuesSuccessCount(executeFunc, tc.Timeout, tc.ScanInterval, tc.ExpectedSuccessCount)
+	newList := func() client.ObjectList { return &appsv1.DeploymentList{} }
+	isReady := func(objects map[types.NamespacedName]client.Object) (bool, error) {
+		return isDeployed(objects, targets)
+	}
+	return watch.WaitFor(k8sClient, newList, targets, isReady, tc)
 }
 
-func isDeployed(k8sClient client.Client, deployments []string, namespace string) (bool, error) {
-	// check deployment status
-	opts := []client.ListOption{
-		client.InNamespace(namespace),
-	}
-	deploymentList, err := List(k8sClient, opts)
-	if err != nil {
-		return false, err
-	}
+func isDeployed(objects map[types.NamespacedName]client.Object, targets []types.NamespacedName) (bool, error) {
 	notready := []string{}
-	for _, name := range deployments {
-		found := false
-		for _, dep := range deploymentList.Items {
-			if dep.Namespace == namespace && dep.Name == name {
-				found = true
-				if dep.Status.Replicas != dep.Status.ReadyReplicas {
-					notready = append(notready, dep.Name)
-				}
-			}
+	for _, target := range targets {
+		dep, ok := objects[target].(*appsv1.Deployment)
+		if !ok {
+			notready = append(notready, target.Name)
+			continue
 		}
-		if !found {
-			notready = append(notready, name)
+		if dep.Status.Replicas != dep.Status.ReadyReplicas {
+			notready = append(notready, dep.Name)
 		}
 	}
 
 	if len(notready) == 0 { // all deployments success
-		zap.L().Debug("deployments are running", zap.String("namespace", namespace), zap.Any("deployments", deployments))
+		zap.L().Debug("deployments are running", zap.Any("deployments", targets))
 		return true, nil
 	}
-	zap.L().Debug("waiting for deployment", zap.String("namespace", namespace), zap.Any("deployments", notready))
+	zap.L().Debug("waiting for deployment", zap.Any("deployments", notready))
 	return false, nil
 }
@@ -7,6 +7,7 @@ import (
 	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
 	osoperatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -56,3 +57,14 @@ func CreateWithMap(k8sClient client.Client, cfg map[string]interface{}) error {
 	}
 	return k8sClient.Create(context.Background(), icsp)
 }
+
+// IsSpecEqual returns true, if the config has the same spec as the existing ImageContentSourcePolicy
+// empty and nil lists are equal
+func IsSpecEqual(icsp *osoperatorv1alpha1.ImageContentSourcePolicy, cfg map[string]interface{}) (bool, error) {
+	updated := &osoperatorv1alpha1.ImageContentSourcePolicy{}
+	err := formatterUtils.JsonMapToStruct(cfg, updated)
+	if err != nil {
+		return false, err
+	}
+	return equality.Semantic.DeepEqual(icsp.Spec, updated.Spec), nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+
+	osoperatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+)
+
+func TestIsSpecEqual(t *testing.T) {
+	existing := &osoperatorv1alpha1.ImageContentSourcePolicy{
+		Spec: osoperatorv1alpha1.ImageContentSourcePolicySpec{
+			RepositoryDigestMirrors: []osoperatorv1alpha1.RepositoryDigestMirrors{
+				{Source: "registry.redhat.io", Mirrors: []string{"mirror.example.com/redhat"}},
+				{Source: "quay.io"},
+			},
+		},
+	}
+
+	sameSpec := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "mirrors"},
+		"spec": map[string]interface{}{
+			"repositoryDigestMirrors": []interface{}{
+				map[string]interface{}{"source": "registry.redhat.io", "mirrors": []interface{}{"mirror.example.com/redhat"}},
+				map[string]interface{}{"source": "quay.io", "mirrors": []interface{}{}},
+			},
+		},
+	}
+	isEqual, err := IsSpecEqual(existing, sameSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isEqual {
+		t.Errorf("expected equal spec")
+	}
+
+	differentSpec := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"repositoryDigestMirrors": []interface{}{
+				map[string]interface{}{"source": "registry.redhat.io", "mirrors": []interface{}{"mirror2.example.com/redhat"}},
+			},
+		},
+	}
+	isEqual, err = IsSpecEqual(existing, differentSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isEqual {
+		t.Errorf("expected different spec")
+	}
+}
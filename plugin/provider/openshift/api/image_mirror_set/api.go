@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	osoperatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	groupVersion = "config.openshift.io/v1"
+)
+
+// mirrors field name of the spec
+var specFields = map[string]string{
+	openshiftTY.KindImageDigestMirrorSet: "imageDigestMirrors",
+	openshiftTY.KindImageTagMirrorSet:    "imageTagMirrors",
+}
+
+// GVK returns GroupVersionKind of ImageDigestMirrorSet or ImageTagMirrorSet
+func GVK(kind string) (schema.GroupVersionKind, error) {
+	if _, found := specFields[kind]; !found {
+		return schema.GroupVersionKind{}, fmt.Errorf("unsupported image mirror set kind:%s", kind)
+	}
+	return schema.FromAPIVersionAndKind(groupVersion, kind), nil
+}
+
+func List(k8sClient client.Client, kind string, opts []client.ListOption) (*unstructured.UnstructuredList, error) {
+	gvk, err := GVK(kind)
+	if err != nil {
+		return nil, err
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(kind + "List"))
+	err = k8sClient.List(context.Background(), list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func Get(k8sClient client.Client, kind, name string) (*unstructured.Unstructured, error) {
+	gvk, err := GVK(kind)
+	if err != nil {
+		return nil, err
+	}
+	mirrorSet := &unstructured.Unstructured{}
+	mirrorSet.SetGroupVersionKind(gvk)
+	err = k8sClient.Get(context.Background(), client.ObjectKey{Name: name}, mirrorSet)
+	if err != nil {
+		return nil, err
+	}
+	return mirrorSet, nil
+}
+
+func Delete(k8sClient client.Client, mirrorSet *unstructured.Unstructured) error {
+	return utils.IgnoreNotFoundError(k8sClient.Delete(context.Background(), mirrorSet))
+}
+
+func DeleteOfAll(k8sClient client.Client, kind string, opts []client.DeleteAllOfOption) error {
+	gvk, err := GVK(kind)
+	if err != nil {
+		return err
+	}
+	mirrorSet := &unstructured.Unstructured{}
+	mirrorSet.SetGroupVersionKind(gvk)
+	return k8sClient.DeleteAllOf(context.Background(), mirrorSet, opts...)
+}
+
+func Create(k8sClient client.Client, mirrorSet *unstructured.Unstructured) error {
+	return k8sClient.Create(context.Background(), mirrorSet)
+}
+
+func CreateWithMap(k8sClient client.Client, kind string, cfg map[string]interface{}) error {
+	gvk, err := GVK(kind)
+	if err != nil {
+		return err
+	}
+	mirrorSet := &unstructured.Unstructured{Object: cfg}
+	mirrorSet.SetGroupVersionKind(gvk)
+	return k8sClient.Create(context.Background(), mirrorSet)
+}
+
+// IsSpecEqual returns true, if the config has the same spec as the existing mirror set
+// empty and nil lists are equal
+func IsSpecEqual(mirrorSet *unstructured.Unstructured, cfg map[string]interface{}) bool {
+	existingSpec := mirrorSet.Object["spec"]
+	return equality.Semantic.DeepEqual(existingSpec, cfg["spec"])
+}
+
+// New returns ImageDigestMirrorSet or ImageTagMirrorSet with the mirrors
+func New(kind, name string, mirrors []openshiftTY.ImageMirrors) (*unstructured.Unstructured, error) {
+	gvk, err := GVK(kind)
+	if err != nil {
+		return nil, err
+	}
+	// unstructured supports only the json compatible types
+	mirrorsRaw := make([]interface{}, 0)
+	for _, mirror := range mirrors {
+		mirrorRaw := map[string]interface{}{"source": mirror.Source}
+		if len(mirror.Mirrors) > 0 {
+			registries := make([]interface{}, 0)
+			for _, registry := range mirror.Mirrors {
+				registries = append(registries, registry)
+			}
+			mirrorRaw["mirrors"] = registries
+		}
+		if mirror.MirrorSourcePolicy != "" {
+			mirrorRaw["mirrorSourcePolicy"] = mirror.MirrorSourcePolicy
+		}
+		mirrorsRaw = append(mirrorsRaw, mirrorRaw)
+	}
+	mirrorSet := &unstructured.Unstructured{}
+	mirrorSet.SetGroupVersionKind(gvk)
+	mirrorSet.SetName(name)
+	err = unstructured.SetNestedSlice(mirrorSet.Object, mirrorsRaw, "spec", specFields[kind])
+	if err != nil {
+		return nil, err
+	}
+	return mirrorSet, nil
+}
+
+// FromICSP converts ImageContentSourcePolicy to ImageDigestMirrorSet, keeps the same name
+func FromICSP(icsp *osoperatorv1alpha1.ImageContentSourcePolicy) (*unstructured.Unstructured, error) {
+	mirrors := make([]openshiftTY.ImageMirrors, 0)
+	for _, rdm := range icsp.Spec.RepositoryDigestMirrors {
+		mirrors = append(mirrors, openshiftTY.ImageMirrors{Source: rdm.Source, Mirrors: rdm.Mirrors})
+	}
+	mirrorSet, err := New(openshiftTY.KindImageDigestMirrorSet, icsp.Name, mirrors)
+	if err != nil {
+		return nil, err
+	}
+	mirrorSet.SetLabels(icsp.Labels)
+	return mirrorSet, nil
+}
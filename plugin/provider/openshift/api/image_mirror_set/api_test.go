@@ -0,0 +1,76 @@
+package api
+
+import (
+	"testing"
+
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"gopkg.in/yaml.v3"
+)
+
+func TestIsSpecEqual(t *testing.T) {
+	existing, err := New(openshiftTY.KindImageDigestMirrorSet, "mirrors", []openshiftTY.ImageMirrors{
+		{Source: "registry.redhat.io", Mirrors: []string{"mirror.example.com/redhat"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		config   string
+		expected bool
+	}{
+		{
+			name: "same spec",
+			config: `
+metadata:
+  name: mirrors
+spec:
+  imageDigestMirrors:
+  - source: registry.redhat.io
+    mirrors:
+    - mirror.example.com/redhat
+`,
+			expected: true,
+		},
+		{
+			name: "different mirror",
+			config: `
+metadata:
+  name: mirrors
+spec:
+  imageDigestMirrors:
+  - source: registry.redhat.io
+    mirrors:
+    - mirror2.example.com/redhat
+`,
+			expected: false,
+		},
+		{
+			name: "additional source",
+			config: `
+metadata:
+  name: mirrors
+spec:
+  imageDigestMirrors:
+  - source: registry.redhat.io
+    mirrors:
+    - mirror.example.com/redhat
+  - source: quay.io
+`,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		cfg := map[string]interface{}{}
+		err := yaml.Unmarshal([]byte(test.config), &cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		received := IsSpecEqual(existing, cfg)
+		if received != test.expected {
+			t.Errorf("%s: expected:%v, received:%v", test.name, test.expected, received)
+		}
+	}
+}
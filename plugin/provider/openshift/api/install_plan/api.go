@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+
+	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func List(k8sClient client.Client, opts []client.ListOption) (*corsosv1alpha1.InstallPlanList, error) {
+	installPlanList := &corsosv1alpha1.InstallPlanList{}
+	err := k8sClient.List(context.Background(), installPlanList, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return installPlanList, nil
+}
+
+func Get(k8sClient client.Client, name, namespace string) (*corsosv1alpha1.InstallPlan, error) {
+	installPlan := &corsosv1alpha1.InstallPlan{}
+	namespacedName := types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}
+	err := k8sClient.Get(context.Background(), namespacedName, installPlan)
+	if err != nil {
+		return nil, err
+	}
+	return installPlan, nil
+}
+
+// Approve approves the manual InstallPlan
+func Approve(k8sClient client.Client, installPlan *corsosv1alpha1.InstallPlan) error {
+	if installPlan.Spec.Approved {
+		return nil
+	}
+	patch := client.MergeFrom(installPlan.DeepCopy())
+	installPlan.Spec.Approved = true
+	return k8sClient.Patch(context.Background(), installPlan, patch)
+}
+
+// ContainsCSV returns true, if the InstallPlan installs the CSV
+func ContainsCSV(installPlan *corsosv1alpha1.InstallPlan, csvName string) bool {
+	for _, name := range installPlan.Spec.ClusterServiceVersionNames {
+		if name == csvName {
+			return true
+		}
+	}
+	return false
+}
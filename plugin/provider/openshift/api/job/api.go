@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	podAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/pod"
+
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/watch"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func List(k8sClient client.Client, opts []client.ListOption) (*batchv1.JobList, error) {
+	jobList := &batchv1.JobList{}
+	err := k8sClient.List(context.Background(), jobList, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return jobList, nil
+}
+
+func Get(k8sClient client.Client, name, namespace string) (*batchv1.Job, error) {
+	job := &batchv1.Job{}
+	namespacedName := types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}
+	err := k8sClient.Get(context.Background(), namespacedName, job)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListPods returns the pods of the job, sorted by creation time, the latest pod is on the last
+func ListPods(k8sClient client.Client, jobName, namespace string) ([]corev1.Pod, error) {
+	job, err := Get(k8sClient, jobName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if job.Spec.Selector == nil {
+		return nil, fmt.Errorf("job does not have a selector. name:%s, namespace:%s", jobName, namespace)
+	}
+	podList, err := podAPI.List(k8sClient, []client.ListOption{client.InNamespace(namespace), client.MatchingLabels(job.Spec.Selector.MatchLabels)})
+	if err != nil {
+		return nil, err
+	}
+	pods := podList.Items
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+	})
+	return pods, nil
+}
+
+// deletes the job with the pods
+func Delete(k8sClient client.Client, job *batchv1.Job) error {
+	return utils.IgnoreNotFoundError(k8sClient.Delete(context.Background(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+}
+
+func DeleteOfAll(k8sClient client.Client, job *batchv1.Job, opts []client.DeleteAllOfOption) error {
+	if job == nil {
+		job = &batchv1.Job{}
+	}
+	opts = append(opts, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return k8sClient.DeleteAllOf(context.Background(), job, opts...)
+}
+
+func Create(k8sClient client.Client, job *batchv1.Job) error {
+	return k8sClient.Create(context.Background(), job)
+}
+
+func CreateWithMap(k8sClient client.Client, cfg map[string]interface{}) error {
+	job := &batchv1.Job{}
+	err := formatterUtils.JsonMapToStruct(cfg, job)
+	if err != nil {
+		return err
+	}
+	return k8sClient.Create(context.Background(), job)
+}
+
+// GetStatus returns the counts and the completion state of the job
+func GetStatus(job *batchv1.Job) openshiftTY.JobStatus {
+	status := openshiftTY.JobStatus{
+		Name:      job.Name,
+		Namespace: job.Namespace,
+		Active:    job.Status.Active,
+		Succeeded: job.Status.Succeeded,
+		Failed:    job.Status.Failed,
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			status.Completed = true
+		case batchv1.JobFailed:
+			status.Reason = condition.Reason
+			status.Message = condition.Message
+		}
+	}
+	return status
+}
+
+// IsFailed returns true, if the job failed, example: reached the backoffLimit or the activeDeadlineSeconds
+func IsFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// wait for jobs completion, watches the jobs and reacts on the changes
+// returns error, if any of the jobs failed. the failure is decided by the job controller with the backoffLimit
+func WaitForCompletion(k8sClient client.Client, jobs []string, namespace string, tc openshiftTY.TimeoutConfig) error {
+	targets := make([]types.NamespacedName, len(jobs))
+	for index, name := range jobs {
+		targets[index] = types.NamespacedName{Name: name, Namespace: namespace}
+	}
+	newList := func() client.ObjectList { return &batchv1.JobList{} }
+	isReady := func(objects map[types.NamespacedName]client.Object) (bool, error) {
+		return isCompleted(objects, targets)
+	}
+	// a completed job will not change, no need to confirm it multiple times
+	tc.ExpectedSuccessCount = 1
+	return watch.WaitFor(k8sClient, newList, targets, isReady, tc)
+}
+
+func isCompleted(objects map[types.NamespacedName]client.Object, targets []types.NamespacedName) (bool, error) {
+	notCompleted := []string{}
+	for _, target := range targets {
+		job, ok := objects[target].(*batchv1.Job)
+		if !ok {
+			notCompleted = append(notCompleted, target.Name)
+			continue
+		}
+		status := GetStatus(job)
+		if IsFailed(job) {
+			return false, fmt.Errorf("job failed. name:%s, namespace:%s, succeeded:%d, failed:%d, reason:%s, message:%s",
+				status.Name, status.Namespace, status.Succeeded, status.Failed, status.Reason, status.Message)
+		}
+		if !status.Completed {
+			notCompleted = append(notCompleted, job.Name)
+		}
+	}
+
+	if len(notCompleted) == 0 { // all jobs completed
+		zap.L().Debug("jobs are completed", zap.Any("jobs", targets))
+		return true, nil
+	}
+	zap.L().Debug("waiting for jobs completion", zap.Any("jobs", notCompleted))
+	return false, nil
+}
+
+// wait for the job deletion, the job name can not be reused till the deletion completed
+func WaitForDeletion(k8sClient client.Client, name, namespace string, tc openshiftTY.TimeoutConfig) error {
+	target := types.NamespacedName{Name: name, Namespace: namespace}
+	newList := func() client.ObjectList { return &batchv1.JobList{} }
+	isDeleted := func(objects map[types.NamespacedName]client.Object) (bool, error) {
+		_, found := objects[target]
+		return !found, nil
+	}
+	tc.ExpectedSuccessCount = 1
+	return watch.WaitFor(k8sClient, newList, []types.NamespacedName{target}, isDeleted, tc)
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var gvk = schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfigPool"}
+
+// rolloutStartWindow is the time given to the machine config operator to render the new configuration.
+// some changes keep the same rendered configuration, after the window the pool is verified with the current status
+var rolloutStartWindow = time.Minute * 2
+
+func List(k8sClient client.Client, opts []client.ListOption) (*unstructured.UnstructuredList, error) {
+	mcpList := &unstructured.UnstructuredList{}
+	mcpList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	err := k8sClient.List(context.Background(), mcpList, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return mcpList, nil
+}
+
+func Get(k8sClient client.Client, name string) (*unstructured.Unstructured, error) {
+	mcp := &unstructured.Unstructured{}
+	mcp.SetGroupVersionKind(gvk)
+	err := k8sClient.Get(context.Background(), client.ObjectKey{Name: name}, mcp)
+	if err != nil {
+		return nil, err
+	}
+	return mcp, nil
+}
+
+// GetStatus returns the rollout status of the pool
+func GetStatus(mcp *unstructured.Unstructured) openshiftTY.MachineConfigPoolStatus {
+	status := openshiftTY.MachineConfigPoolStatus{
+		Name:       mcp.GetName(),
+		Generation: mcp.GetGeneration(),
+	}
+	status.TargetConfiguration, _, _ = unstructured.NestedString(mcp.Object, "spec", "configuration", "name")
+	status.Configuration, _, _ = unstructured.NestedString(mcp.Object, "status", "configuration", "name")
+	status.ObservedGeneration, _, _ = unstructured.NestedInt64(mcp.Object, "status", "observedGeneration")
+	status.MachineCount, _, _ = unstructured.NestedInt64(mcp.Object, "status", "machineCount")
+	status.UpdatedMachineCount, _, _ = unstructured.NestedInt64(mcp.Object, "status", "updatedMachineCount")
+	status.ReadyMachineCount, _, _ = unstructured.NestedInt64(mcp.Object, "status", "readyMachineCount")
+	status.UnavailableMachineCount, _, _ = unstructured.NestedInt64(mcp.Object, "status", "unavailableMachineCount")
+	status.DegradedMachineCount, _, _ = unstructured.NestedInt64(mcp.Object, "status", "degradedMachineCount")
+
+	conditions, _, _ := unstructured.NestedSlice(mcp.Object, "status", "conditions")
+	for _, rawCondition := range conditions {
+		condition, ok := rawCondition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		isTrue := condition["status"] == "True"
+		switch condition["type"] {
+		case "Updated":
+			status.Updated = isTrue
+		case "Updating":
+			status.Updating = isTrue
+		case "Degraded":
+			status.Degraded = isTrue
+		}
+	}
+	return status
+}
+
+// ListStatus returns the status of the given pools, all the pools, if names are empty
+func ListStatus(k8sClient client.Client, names []string) ([]openshiftTY.MachineConfigPoolStatus, error) {
+	mcpList, err := List(k8sClient, []client.ListOption{})
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]openshiftTY.MachineConfigPoolStatus, 0)
+	for index := range mcpList.Items {
+		mcp := &mcpList.Items[index]
+		if len(names) > 0 && !utils.MatchString(names, mcp.GetName()) {
+			continue
+		}
+		statuses = append(statuses, GetStatus(mcp))
+	}
+	if len(names) > 0 && len(statuses) == 0 {
+		return nil, fmt.Errorf("machine config pools not found. names:%v", names)
+	}
+	return statuses, nil
+}
+
+// Snapshot returns the target configuration of the given pools, all the pools, if names are empty
+// taken before a change, used to detect the start of the rollout
+func Snapshot(k8sClient client.Client, names []string) (map[string]string, error) {
+	statuses, err := ListStatus(k8sClient, names)
+	if err != nil {
+		return nil, err
+	}
+	configurations := map[string]string{}
+	for _, status := range statuses {
+		configurations[status.Name] = status.TargetConfiguration
+	}
+	return configurations, nil
+}
+
+// WaitForRollout waits till the given pools updated to the target configuration, all the pools, if names are empty
+// the machine config operator takes a while to render the new configuration, with the snapshot taken before the change,
+// a pool is verified only after the target configuration changed, the pool started updating
+// or the start window elapsed. without snapshot, waits for the current rollout
+func WaitForRollout(k8sClient client.Client, names []string, snapshot map[string]string, tc openshiftTY.TimeoutConfig) error {
+	tc.UpdateRolloutDefaults()
+	progress := map[string]string{}
+	started := map[string]bool{}
+	startTime := time.Now()
+	executeFunc := func() (bool, error) {
+		statuses, err := ListStatus(k8sClient, names)
+		if err != nil {
+			return false, err
+		}
+		rolledOut := true
+		for _, status := range statuses {
+			if !started[status.Name] {
+				previousConfiguration, found := snapshot[status.Name]
+				started[status.Name] = snapshot == nil || !found || status.TargetConfiguration != previousConfiguration || status.Updating
+				if !started[status.Name] && time.Since(startTime) >= rolloutStartWindow {
+					zap.L().Warn("rollout not started in the window, the configuration may not be changed. verifying the current status", zap.String("name", status.Name), zap.String("configuration", previousConfiguration), zap.String("window", rolloutStartWindow.String()))
+					started[status.Name] = true
+				}
+				if !started[status.Name] {
+					zap.L().Debug("waiting for the rollout to start", zap.String("name", status.Name), zap.String("configuration", previousConfiguration))
+					rolledOut = false
+					continue
+				}
+			}
+			// reports only the changes
+			currentProgress := status.Progress()
+			if progress[status.Name] != currentProgress {
+				progress[status.Name] = currentProgress
+				zap.L().Info("machine config pool status", zap.String("name", status.Name), zap.String("progress", currentProgress), zap.Bool("updating", status.Updating), zap.Bool("degraded", status.Degraded))
+			}
+			if !status.IsRolledOut() {
+				rolledOut = false
+			}
+		}
+		return rolledOut, nil
+	}
+	err := funcUtils.ExecuteWithTimeoutAndContinuesSuccessCount(executeFunc, tc.Timeout, tc.ScanInterval, tc.ExpectedSuccessCount)
+	if err != nil {
+		zap.L().Error("machine config pools are not rolled out", zap.Any("progress", progress), zap.Any("started", started), zap.Error(err))
+		return err
+	}
+	return nil
+}
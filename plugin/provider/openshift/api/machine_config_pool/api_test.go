@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testTimeout = openshiftTY.TimeoutConfig{Timeout: time.Millisecond * 500, ScanInterval: time.Millisecond * 10, ExpectedSuccessCount: 2}
+
+// returns a rolled out pool on the given configuration
+func newPool(name, configuration string) *unstructured.Unstructured {
+	mcp := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"configuration": map[string]interface{}{"name": configuration},
+		},
+		"status": map[string]interface{}{
+			"configuration":           map[string]interface{}{"name": configuration},
+			"machineCount":            int64(3),
+			"updatedMachineCount":     int64(3),
+			"readyMachineCount":       int64(3),
+			"unavailableMachineCount": int64(0),
+			"degradedMachineCount":    int64(0),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Updated", "status": "True"},
+				map[string]interface{}{"type": "Updating", "status": "False"},
+				map[string]interface{}{"type": "Degraded", "status": "False"},
+			},
+		},
+	}}
+	mcp.SetGroupVersionKind(gvk)
+	mcp.SetName(name)
+	return mcp
+}
+
+func newFakeClient(objects ...client.Object) client.Client {
+	// registered upfront, the fake client registers the unstructured types on the first use, not thread safe
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestWaitForRolloutWithoutSnapshot(t *testing.T) {
+	k8sClient := newFakeClient(newPool("master", "rendered-master-1"), newPool("worker", "rendered-worker-1"))
+	err := WaitForRollout(k8sClient, nil, nil, testTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitForRolloutNotStarted(t *testing.T) {
+	// the new configuration is not rendered yet, the pools look rolled out
+	k8sClient := newFakeClient(newPool("master", "rendered-master-1"), newPool("worker", "rendered-worker-1"))
+	snapshot, err := Snapshot(k8sClient, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = WaitForRollout(k8sClient, nil, snapshot, testTimeout)
+	if err == nil {
+		t.Fatal("expected timeout error, rollout not started")
+	}
+}
+
+func TestWaitForRolloutAfterChange(t *testing.T) {
+	k8sClient := newFakeClient(newPool("master", "rendered-master-1"), newPool("worker", "rendered-worker-1"))
+	snapshot, err := Snapshot(k8sClient, []string{"worker"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot["worker"] != "rendered-worker-1" || len(snapshot) != 1 {
+		t.Fatalf("unexpected snapshot: %v", snapshot)
+	}
+
+	// machine config operator renders the new configuration and rolls out
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		updated := newPool("worker", "rendered-worker-2")
+		existing, err := Get(k8sClient, "worker")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		updated.SetResourceVersion(existing.GetResourceVersion())
+		err = k8sClient.Update(context.Background(), updated)
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	err = WaitForRollout(k8sClient, []string{"worker"}, snapshot, testTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitForRolloutUpdating(t *testing.T) {
+	// pool started updating, but not rolled out yet
+	mcp := newPool("worker", "rendered-worker-1")
+	_ = unstructured.SetNestedField(mcp.Object, "rendered-worker-2", "spec", "configuration", "name")
+	_ = unstructured.SetNestedField(mcp.Object, int64(1), "status", "updatedMachineCount")
+	k8sClient := newFakeClient(mcp)
+
+	err := WaitForRollout(k8sClient, nil, map[string]string{"worker": "rendered-worker-1"}, testTimeout)
+	if err == nil {
+		t.Fatal("expected timeout error, rollout in progress")
+	}
+}
+
+func TestWaitForRolloutStartWindow(t *testing.T) {
+	// the change keeps the same rendered configuration, verified after the start window
+	defer func(window time.Duration) { rolloutStartWindow = window }(rolloutStartWindow)
+	rolloutStartWindow = time.Millisecond * 50
+
+	k8sClient := newFakeClient(newPool("master", "rendered-master-1"), newPool("worker", "rendered-worker-1"))
+	snapshot, err := Snapshot(k8sClient, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = WaitForRollout(k8sClient, nil, snapshot, testTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
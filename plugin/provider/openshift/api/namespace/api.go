@@ -8,8 +8,8 @@ import (
 
 	"github.com/jkandasa/autoeasy/pkg/utils"
 	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
-	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/watch"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -90,32 +90,24 @@ func CreateIfNotAvailable(k8sClient client.Client, name string) error {
 	return k8sClient.Create(context.Background(), namespace)
 }
 
-// wait for namespaces deletion
+// wait for namespaces deletion, watches the namespaces and reacts on the changes
 func WaitForDeletion(k8sClient client.Client, namespaces []string, tc openshiftTY.TimeoutConfig) error {
-	executeFunc := func() (bool, error) {
-		return isAbsent(k8sClient, namespaces)
+	targets := make([]types.NamespacedName, len(namespaces))
+	for index, name := range namespaces {
+		targets[index] = types.NamespacedName{Name: name}
 	}
-	return funcUtils.ExecuteWithTimeoutAndContinuesSuccessCount(executeFunc, tc.Timeout, tc.ScanInterval, tc.ExpectedSuccessCount)
+	newList := func() client.ObjectList { return &corev1.NamespaceList{} }
+	isReady := func(objects map[types.NamespacedName]client.Object) (bool, error) {
+		return isAbsent(objects, namespaces)
+	}
+	return watch.WaitFor(k8sClient, newList, targets, isReady, tc)
 }
 
-func isAbsent(k8sClient client.Client, namespaces []string) (bool, error) {
-	opts := []client.ListOption{
-		client.InNamespace(""),
-	}
-	nsList, err := List(k8sClient, opts)
-	if err != nil {
-		return false, err
-	}
+func isAbsent(objects map[types.NamespacedName]client.Object, namespaces []string) (bool, error) {
 	availableList := []string{}
-	for _, _namespace := range namespaces {
-		found := false
-		for _, ns := range nsList.Items {
-			if ns.Name == _namespace {
-				found = true
-			}
-		}
-		if found {
-			availableList = append(availableList, _namespace)
+	for _, namespace := range namespaces {
+		if _, found := objects[types.NamespacedName{Name: namespace}]; found {
+			availableList = append(availableList, namespace)
 		}
 	}
 
@@ -1,9 +1,15 @@
 package api
 
 import (
+	"fmt"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
 	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
 	csvAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/cluster_service_version"
 	deploymentAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/deployment"
+	installPlanAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/install_plan"
+	operatorGroupAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/operator_group"
+	packageManifestAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/package_manifest"
 	subscriptionAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/subscription"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	mcUtils "github.com/mycontroller-org/server/v2/pkg/utils"
@@ -12,92 +18,42 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// UninstallWithMap removes the Subscription and ClusterServiceVersion
-func UninstallWithMap(k8sClient client.Client, cfg map[string]interface{}) error {
+func InstallWithMap(k8sClient client.Client, cfg map[string]interface{}, ogCfg openshiftTY.OperatorGroupConfig, tc openshiftTY.TimeoutConfig) error {
 	subscription := &corsosv1alpha1.Subscription{}
 	err := mcUtils.MapToStruct(mcUtils.TagNameJSON, cfg, subscription)
 	if err != nil {
 		return err
 	}
-	return Uninstall(k8sClient, subscription)
-}
-
-// Uninstall removes the Subscription and ClusterServiceVersion
-func Uninstall(k8sClient client.Client, subscription *corsosv1alpha1.Subscription) error {
-	opts := []client.ListOption{
-		client.InNamespace(""),
-	}
 
-	subscriptionList, err := subscriptionAPI.List(k8sClient, opts)
+	err = EnsureOperatorGroup(k8sClient, subscription, ogCfg)
 	if err != nil {
 		return err
 	}
-
-	// get the match and remove subscription and csv
-	for _, rxSub := range subscriptionList.Items {
-		if subscription.Name == rxSub.Name {
-			installedCSV := rxSub.Status.InstalledCSV
-			removableCSVs := []string{installedCSV}
-			if installedCSV != rxSub.Status.CurrentCSV {
-				removableCSVs = append(removableCSVs, rxSub.Status.CurrentCSV)
-			}
-
-			// remove csv
-			csvList, err := csvAPI.List(k8sClient, opts)
-			if err != nil {
-				return err
-			}
-			for _, csv := range csvList.Items {
-				if _, remove := mcUtils.FindItem(removableCSVs, csv.Name); remove {
-					err = csvAPI.Delete(k8sClient, &csv)
-					if err != nil {
-						zap.L().Error("error on csv deletion", zap.String("name", csv.Name), zap.String("namespace", csv.Namespace), zap.Error(err))
-						return err
-					}
-				}
-			}
-
-			// remove subscription
-			err = subscriptionAPI.Delete(k8sClient, &rxSub)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
+	return Install(k8sClient, subscription, tc)
 }
 
-func InstallWithMap(k8sClient client.Client, cfg map[string]interface{}, tc openshiftTY.TimeoutConfig) error {
-	subscription := &corsosv1alpha1.Subscription{}
-	err := mcUtils.MapToStruct(mcUtils.TagNameJSON, cfg, subscription)
+// EnsureOperatorGroup creates or verifies the OperatorGroup of the subscription namespace
+// based on the supported install modes of the package
+func EnsureOperatorGroup(k8sClient client.Client, subscription *corsosv1alpha1.Subscription, cfg openshiftTY.OperatorGroupConfig) error {
+	if cfg.Skip || subscription.Spec == nil {
+		return nil
+	}
+	spec := subscription.Spec
+	supportedModes, err := packageManifestAPI.GetInstallModes(k8sClient, spec.Package, spec.Channel, spec.CatalogSource, spec.CatalogSourceNamespace)
 	if err != nil {
-		return err
+		// continues without install modes verification
+		zap.L().Warn("unable to get the install modes of the package", zap.String("package", spec.Package), zap.String("channel", spec.Channel), zap.String("catalogSource", spec.CatalogSource), zap.Error(err))
 	}
-
-	return Install(k8sClient, subscription, tc)
+	return operatorGroupAPI.Ensure(k8sClient, subscription.Namespace, supportedModes, cfg)
 }
 
+// Install creates the subscription and waits for the deployments of the operator
+// upgrades are handled by UpgradePath
 func Install(k8sClient client.Client, subscriptionCfg *corsosv1alpha1.Subscription, tc openshiftTY.TimeoutConfig) error {
-	return apply(k8sClient, subscriptionCfg, tc, false)
-}
-
-func Upgrade(k8sClient client.Client, subscriptionCfg *corsosv1alpha1.Subscription, tc openshiftTY.TimeoutConfig) error {
-	return apply(k8sClient, subscriptionCfg, tc, true)
-}
-
-func apply(k8sClient client.Client, subscriptionCfg *corsosv1alpha1.Subscription, tc openshiftTY.TimeoutConfig, isUpgrade bool) error {
-	// create/upgrade subscription
-	if isUpgrade {
-		err := subscriptionAPI.Update(k8sClient, subscriptionCfg)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := subscriptionAPI.Create(k8sClient, subscriptionCfg)
-		if err != nil {
-			return err
-		}
+	// create subscription
+	err := subscriptionAPI.Create(k8sClient, subscriptionCfg)
+	if err != nil {
+		return err
 	}
 
 	// get updated subscription
@@ -106,6 +62,14 @@ func apply(k8sClient client.Client, subscriptionCfg *corsosv1alpha1.Subscription
 		return err
 	}
 
+	// approve the InstallPlan, on manual approval
+	if subscription.Spec != nil && subscription.Spec.InstallPlanApproval == corsosv1alpha1.ApprovalManual {
+		err = approveInstallPlan(k8sClient, subscription, tc)
+		if err != nil {
+			return err
+		}
+	}
+
 	// get deployments
 	deployments := []string{}
 	executeFunc := func() (bool, error) {
@@ -125,6 +89,49 @@ func apply(k8sClient client.Client, subscriptionCfg *corsosv1alpha1.Subscription
 	return deploymentAPI.WaitForDeployments(k8sClient, deployments, subscription.Namespace, tc)
 }
 
+// waits for the InstallPlan of the subscription, verifies the expected CSV and approves it.
+// expected CSV is the startingCSV, if supplied, otherwise the current CSV of the subscription
+func approveInstallPlan(k8sClient client.Client, subscription *corsosv1alpha1.Subscription, tc openshiftTY.TimeoutConfig) error {
+	var installPlan *corsosv1alpha1.InstallPlan
+	executeFunc := func() (bool, error) {
+		_subscription, err := subscriptionAPI.Get(k8sClient, subscription.Name, subscription.Namespace)
+		if err != nil {
+			return false, err
+		}
+		if _subscription.Status.InstallPlanRef == nil {
+			zap.L().Debug("waiting for InstallPlan", zap.String("subscription", subscription.Name), zap.String("namespace", subscription.Namespace))
+			return false, nil
+		}
+		ref := _subscription.Status.InstallPlanRef
+		_installPlan, err := installPlanAPI.Get(k8sClient, ref.Name, ref.Namespace)
+		if err != nil {
+			return false, utils.IgnoreNotFoundError(err)
+		}
+
+		expectedCSV := subscription.Spec.StartingCSV
+		if expectedCSV == "" {
+			expectedCSV = _subscription.Status.CurrentCSV
+		}
+		if expectedCSV != "" && !installPlanAPI.ContainsCSV(_installPlan, expectedCSV) {
+			return false, fmt.Errorf("InstallPlan does not contain the expected CSV. installPlan:%s, namespace:%s, expectedCSV:%s, csvNames:%v",
+				_installPlan.Name, _installPlan.Namespace, expectedCSV, _installPlan.Spec.ClusterServiceVersionNames)
+		}
+		installPlan = _installPlan
+		return true, nil
+	}
+	err := funcUtils.ExecuteWithTimeout(executeFunc, tc.Timeout, tc.ScanInterval)
+	if err != nil {
+		return err
+	}
+
+	err = installPlanAPI.Approve(k8sClient, installPlan)
+	if err != nil {
+		return err
+	}
+	zap.L().Info("InstallPlan approved", zap.String("name", installPlan.Name), zap.String("namespace", installPlan.Namespace), zap.Strings("csvNames", installPlan.Spec.ClusterServiceVersionNames))
+	return nil
+}
+
 func getDeployments(k8sClient client.Client, subscriptionName, namespace string) ([]string, error) {
 	deployments := []string{}
 	zap.L().Debug("operator deployment details not available. getting deployments details", zap.String("subscriptionName", subscriptionName), zap.String("namespace", namespace))
@@ -0,0 +1,275 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
+	csvAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/cluster_service_version"
+	deploymentAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/deployment"
+	installPlanAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/install_plan"
+	operatorGroupAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/operator_group"
+	subscriptionAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/subscription"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	mcUtils "github.com/mycontroller-org/server/v2/pkg/utils"
+	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CustomResourceDefinition is not registered in the scheme
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// UninstallWithMap removes the Subscription and ClusterServiceVersion
+// and the resources enabled on the uninstall config
+func UninstallWithMap(k8sClient client.Client, cfg map[string]interface{}, uc openshiftTY.UninstallConfig, tc openshiftTY.TimeoutConfig) error {
+	subscription := &corsosv1alpha1.Subscription{}
+	err := mcUtils.MapToStruct(mcUtils.TagNameJSON, cfg, subscription)
+	if err != nil {
+		return err
+	}
+	if uc.IsDefined() {
+		return UninstallWithCleanup(k8sClient, subscription, uc, tc)
+	}
+	return Uninstall(k8sClient, subscription)
+}
+
+// Uninstall removes the Subscription and ClusterServiceVersion
+func Uninstall(k8sClient client.Client, subscription *corsosv1alpha1.Subscription) error {
+	return uninstall(k8sClient, subscription, nil, openshiftTY.TimeoutConfig{})
+}
+
+// UninstallWithCleanup removes the Subscription and ClusterServiceVersion
+// and the resources enabled on the uninstall config. waits till the operator deployments removed
+func UninstallWithCleanup(k8sClient client.Client, subscription *corsosv1alpha1.Subscription, uc openshiftTY.UninstallConfig, tc openshiftTY.TimeoutConfig) error {
+	uc.Update()
+	tc.UpdateDefaults()
+	return uninstall(k8sClient, subscription, &uc, tc)
+}
+
+func uninstall(k8sClient client.Client, subscription *corsosv1alpha1.Subscription, uc *openshiftTY.UninstallConfig, tc openshiftTY.TimeoutConfig) error {
+	opts := []client.ListOption{
+		client.InNamespace(""),
+	}
+
+	subscriptionList, err := subscriptionAPI.List(k8sClient, opts)
+	if err != nil {
+		return err
+	}
+
+	// get the match and remove subscription and csv
+	// the cleanup removes the cluster wide resources, matches the namespace, if supplied
+	for _, rxSub := range subscriptionList.Items {
+		if subscription.Name == rxSub.Name && (subscription.Namespace == "" || subscription.Namespace == rxSub.Namespace) {
+			installedCSV := rxSub.Status.InstalledCSV
+			removableCSVs := []string{installedCSV}
+			if installedCSV != rxSub.Status.CurrentCSV {
+				removableCSVs = append(removableCSVs, rxSub.Status.CurrentCSV)
+			}
+
+			// collect the owned CRDs and deployments of the csv, before the removal
+			var ownedCRDs []corsosv1alpha1.CRDDescription
+			var deployments []string
+			if uc != nil && installedCSV != "" {
+				csv, err := csvAPI.Get(k8sClient, installedCSV, rxSub.Namespace)
+				if utils.IgnoreNotFoundError(err) != nil {
+					return err
+				}
+				if csv != nil {
+					ownedCRDs = csv.Spec.CustomResourceDefinitions.Owned
+					for _, deployment := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+						deployments = append(deployments, deployment.Name)
+					}
+				}
+			}
+
+			// remove operands, when the operator is running
+			if uc != nil && uc.RemoveOperands {
+				err = removeOperands(k8sClient, ownedCRDs, tc)
+				if err != nil {
+					return err
+				}
+			}
+
+			// remove csv, the copied CSVs on the other namespaces are removed by OLM
+			csvList, err := csvAPI.List(k8sClient, []client.ListOption{client.InNamespace(rxSub.Namespace)})
+			if err != nil {
+				return err
+			}
+			for _, csv := range csvList.Items {
+				if _, remove := mcUtils.FindItem(removableCSVs, csv.Name); remove {
+					err = csvAPI.Delete(k8sClient, &csv)
+					if err != nil {
+						zap.L().Error("error on csv deletion", zap.String("name", csv.Name), zap.String("namespace", csv.Namespace), zap.Error(err))
+						return err
+					}
+				}
+			}
+
+			// remove subscription
+			err = subscriptionAPI.Delete(k8sClient, &rxSub)
+			if err != nil {
+				return err
+			}
+
+			if uc == nil {
+				continue
+			}
+
+			err = cleanup(k8sClient, &rxSub, removableCSVs, ownedCRDs, deployments, uc, tc)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// removes the leftovers of the operator and waits for the operator deployments removal
+func cleanup(k8sClient client.Client, subscription *corsosv1alpha1.Subscription, csvNames []string, ownedCRDs []corsosv1alpha1.CRDDescription, deployments []string, uc *openshiftTY.UninstallConfig, tc openshiftTY.TimeoutConfig) error {
+	namespace := subscription.Namespace
+
+	if len(deployments) > 0 {
+		err := waitForDeploymentsRemoval(k8sClient, deployments, namespace, tc)
+		if err != nil {
+			return err
+		}
+	}
+
+	if uc.RemoveCRDs {
+		for _, ownedCRD := range ownedCRDs {
+			crd := &unstructured.Unstructured{}
+			crd.SetGroupVersionKind(crdGVK)
+			crd.SetName(ownedCRD.Name)
+			err := utils.IgnoreNotFoundError(k8sClient.Delete(context.Background(), crd))
+			if err != nil {
+				return err
+			}
+			zap.L().Debug("deleted a CustomResourceDefinition", zap.String("name", ownedCRD.Name))
+		}
+	}
+
+	if uc.RemoveInstallPlans {
+		installPlanList, err := installPlanAPI.List(k8sClient, []client.ListOption{client.InNamespace(namespace)})
+		if err != nil {
+			return err
+		}
+		for _, installPlan := range installPlanList.Items {
+			for _, csvName := range csvNames {
+				if csvName != "" && installPlanAPI.ContainsCSV(&installPlan, csvName) {
+					err = utils.IgnoreNotFoundError(k8sClient.Delete(context.Background(), &installPlan))
+					if err != nil {
+						return err
+					}
+					zap.L().Debug("deleted an InstallPlan", zap.String("name", installPlan.Name), zap.String("namespace", namespace))
+					break
+				}
+			}
+		}
+	}
+
+	// the OperatorGroup is shared by all the operators of the namespace
+	if uc.RemoveOperatorGroup {
+		subscriptionList, err := subscriptionAPI.List(k8sClient, []client.ListOption{client.InNamespace(namespace)})
+		if err != nil {
+			return err
+		}
+		if len(subscriptionList.Items) > 0 {
+			zap.L().Info("OperatorGroup used by other subscriptions, not removed", zap.String("namespace", namespace), zap.Int("subscriptions", len(subscriptionList.Items)))
+			return nil
+		}
+		operatorGroupList, err := operatorGroupAPI.List(k8sClient, []client.ListOption{client.InNamespace(namespace)})
+		if err != nil {
+			return err
+		}
+		for _, operatorGroup := range operatorGroupList.Items {
+			err = operatorGroupAPI.Delete(k8sClient, &operatorGroup)
+			if err != nil {
+				return err
+			}
+			zap.L().Debug("deleted an OperatorGroup", zap.String("name", operatorGroup.Name), zap.String("namespace", namespace))
+		}
+	}
+	return nil
+}
+
+// removes the custom resources of the owned CRDs from all the namespaces and waits for the removal
+func removeOperands(k8sClient client.Client, ownedCRDs []corsosv1alpha1.CRDDescription, tc openshiftTY.TimeoutConfig) error {
+	gvks := make([]schema.GroupVersionKind, 0)
+	for _, ownedCRD := range ownedCRDs {
+		// CRD name format: <plural>.<group>
+		nameParts := strings.SplitN(ownedCRD.Name, ".", 2)
+		if len(nameParts) != 2 {
+			return fmt.Errorf("invalid CRD name:%s", ownedCRD.Name)
+		}
+		gvks = append(gvks, schema.GroupVersionKind{Group: nameParts[1], Version: ownedCRD.Version, Kind: ownedCRD.Kind + "List"})
+	}
+
+	listOperands := func(gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		err := k8sClient.List(context.Background(), list)
+		if err != nil {
+			// the CRD or the version may not be served anymore
+			if meta.IsNoMatchError(err) {
+				return nil, nil
+			}
+			return nil, utils.IgnoreNotFoundError(err)
+		}
+		return list.Items, nil
+	}
+
+	for _, gvk := range gvks {
+		operands, err := listOperands(gvk)
+		if err != nil {
+			return err
+		}
+		for index := range operands {
+			operand := &operands[index]
+			err = utils.IgnoreNotFoundError(k8sClient.Delete(context.Background(), operand))
+			if err != nil {
+				return err
+			}
+			zap.L().Debug("deleted an operand", zap.String("kind", operand.GetKind()), zap.String("name", operand.GetName()), zap.String("namespace", operand.GetNamespace()))
+		}
+	}
+
+	// wait till the operator processed the finalizers
+	executeFunc := func() (bool, error) {
+		for _, gvk := range gvks {
+			operands, err := listOperands(gvk)
+			if err != nil {
+				return false, err
+			}
+			if len(operands) > 0 {
+				zap.L().Debug("waiting for operands removal", zap.String("kind", gvk.Kind), zap.Int("count", len(operands)))
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	return funcUtils.ExecuteWithTimeout(executeFunc, tc.Timeout, tc.ScanInterval)
+}
+
+func waitForDeploymentsRemoval(k8sClient client.Client, deployments []string, namespace string, tc openshiftTY.TimeoutConfig) error {
+	executeFunc := func() (bool, error) {
+		for _, name := range deployments {
+			_, err := deploymentAPI.Get(k8sClient, name, namespace)
+			if err == nil {
+				zap.L().Debug("waiting for operator deployment removal", zap.String("name", name), zap.String("namespace", namespace))
+				return false, nil
+			}
+			if utils.IgnoreNotFoundError(err) != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+	return funcUtils.ExecuteWithTimeout(executeFunc, tc.Timeout, tc.ScanInterval)
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	csvAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/cluster_service_version"
+	subscriptionAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/subscription"
+	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newCSV(name, namespace string) *corsosv1alpha1.ClusterServiceVersion {
+	return &corsosv1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+}
+
+func TestUninstallMatchesNamespace(t *testing.T) {
+	subscriptionA := newSubscription(corsosv1alpha1.ApprovalAutomatic, "my-operator.v1", "my-operator.v1", corsosv1alpha1.SubscriptionStateAtLatest, "")
+	subscriptionA.Namespace = "team-a"
+	subscriptionB := newSubscription(corsosv1alpha1.ApprovalAutomatic, "my-operator.v1", "my-operator.v1", corsosv1alpha1.SubscriptionStateAtLatest, "")
+	subscriptionB.Namespace = "team-b"
+	k8sClient := newFakeClient(t, subscriptionA, subscriptionB, newCSV("my-operator.v1", "team-a"), newCSV("my-operator.v1", "team-b"))
+
+	request := &corsosv1alpha1.Subscription{ObjectMeta: metav1.ObjectMeta{Name: "my-operator", Namespace: "team-a"}}
+	err := Uninstall(k8sClient, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = subscriptionAPI.Get(k8sClient, "my-operator", "team-a")
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the subscription of team-a removed, error:%v", err)
+	}
+	_, err = csvAPI.Get(k8sClient, "my-operator.v1", "team-a")
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the csv of team-a removed")
+	}
+
+	_, err = subscriptionAPI.Get(k8sClient, "my-operator", "team-b")
+	if err != nil {
+		t.Errorf("subscription of team-b should not be removed, error:%v", err)
+	}
+	_, err = csvAPI.Get(k8sClient, "my-operator.v1", "team-b")
+	if err != nil {
+		t.Errorf("csv of team-b should not be removed, error:%v", err)
+	}
+}
+
+// returns no match error for the unstructured lists, the CRD is not served
+type noMatchClient struct {
+	client.Client
+}
+
+func (c *noMatchClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if unstructuredList, ok := list.(*unstructured.UnstructuredList); ok {
+		gvk := unstructuredList.GroupVersionKind()
+		return &meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{gvk.Version}}
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestRemoveOperandsNoMatch(t *testing.T) {
+	k8sClient := &noMatchClient{Client: newFakeClient(t)}
+	ownedCRDs := []corsosv1alpha1.CRDDescription{{Name: "backups.example.com", Version: "v1", Kind: "Backup"}}
+	err := removeOperands(k8sClient, ownedCRDs, testTimeout)
+	if err != nil {
+		t.Fatalf("expected no match treated as empty, error:%v", err)
+	}
+
+	// verify the error type used on the test
+	err = k8sClient.List(context.Background(), &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": schema.GroupVersion{Group: "example.com", Version: "v1"}.String(), "kind": "BackupList"}})
+	if !meta.IsNoMatchError(err) {
+		t.Fatalf("expected no match error, received:%v", err)
+	}
+}
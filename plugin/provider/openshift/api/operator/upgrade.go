@@ -0,0 +1,208 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
+	csvAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/cluster_service_version"
+	installPlanAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/install_plan"
+	subscriptionAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/subscription"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// number of the CSV conditions included on the stuck hop error
+const csvConditionsLimit = 5
+
+// UpgradePath walks the operator through the upgrade graph one hop at a time.
+// the subscription is switched to the manual approval, each InstallPlan is approved
+// and waits for the CSV "Succeeded" phase. fails on the first stuck hop.
+// the original approval of the subscription is restored on return
+func UpgradePath(k8sClient client.Client, request openshiftTY.UpgradeRequest, tc openshiftTY.TimeoutConfig) (_ *openshiftTY.UpgradeResult, err error) {
+	startTime := time.Now()
+	subscription, err := subscriptionAPI.Get(k8sClient, request.Name, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Spec == nil {
+		return nil, fmt.Errorf("subscription spec is empty. name:%s, namespace:%s", request.Name, request.Namespace)
+	}
+
+	// update channel and approval, the upgrade hops are controlled by the InstallPlan approval
+	originalApproval := subscription.Spec.InstallPlanApproval
+	updateRequired := originalApproval != corsosv1alpha1.ApprovalManual
+	subscription.Spec.InstallPlanApproval = corsosv1alpha1.ApprovalManual
+	if request.Channel != "" && request.Channel != subscription.Spec.Channel {
+		subscription.Spec.Channel = request.Channel
+		updateRequired = true
+	}
+	if updateRequired {
+		err = subscriptionAPI.Update(k8sClient, subscription)
+		if err != nil {
+			return nil, err
+		}
+		zap.L().Info("subscription updated for the upgrade", zap.String("name", subscription.Name), zap.String("namespace", subscription.Namespace), zap.String("channel", subscription.Spec.Channel))
+	}
+	if originalApproval != corsosv1alpha1.ApprovalManual {
+		defer func() {
+			restoreErr := restoreApproval(k8sClient, subscription.Name, subscription.Namespace, originalApproval)
+			if restoreErr != nil && err == nil {
+				err = restoreErr
+			}
+		}()
+	}
+
+	result := &openshiftTY.UpgradeResult{
+		Name:         subscription.Name,
+		Namespace:    subscription.Namespace,
+		Channel:      subscription.Spec.Channel,
+		InstalledCSV: subscription.Status.InstalledCSV,
+		Hops:         make([]openshiftTY.UpgradeHop, 0),
+	}
+
+	for {
+		if request.TargetCSV != "" && result.InstalledCSV == request.TargetCSV {
+			break
+		}
+		if request.MaxHops > 0 && len(result.Hops) >= request.MaxHops {
+			break
+		}
+
+		installPlan, nextCSV, err := waitForNextInstallPlan(k8sClient, subscription.Name, subscription.Namespace, result.InstalledCSV, tc)
+		if err != nil {
+			return result, err
+		}
+		if installPlan == nil { // reached the latest CSV of the channel
+			if request.TargetCSV != "" {
+				return result, fmt.Errorf("target CSV not reached, operator is at the latest CSV of the channel. installedCSV:%s, targetCSV:%s, channel:%s", result.InstalledCSV, request.TargetCSV, result.Channel)
+			}
+			break
+		}
+
+		hop, err := upgradeHop(k8sClient, subscription, installPlan, result.InstalledCSV, nextCSV, tc)
+		if err != nil {
+			return result, err
+		}
+		result.Hops = append(result.Hops, *hop)
+		result.InstalledCSV = hop.To
+	}
+
+	result.TimeTaken = time.Since(startTime)
+	zap.L().Info("operator upgrade completed", zap.String("name", result.Name), zap.String("namespace", result.Namespace), zap.String("installedCSV", result.InstalledCSV), zap.Int("hops", len(result.Hops)), zap.String("timeTaken", result.TimeTaken.String()))
+	return result, nil
+}
+
+// sets the approval of the subscription back to the original value
+func restoreApproval(k8sClient client.Client, name, namespace string, approval corsosv1alpha1.Approval) error {
+	subscription, err := subscriptionAPI.Get(k8sClient, name, namespace)
+	if err != nil {
+		return fmt.Errorf("error on restoring the subscription approval. name:%s, namespace:%s, approval:%s, error:%w", name, namespace, approval, err)
+	}
+	if subscription.Spec == nil || subscription.Spec.InstallPlanApproval == approval {
+		return nil
+	}
+	subscription.Spec.InstallPlanApproval = approval
+	err = subscriptionAPI.Update(k8sClient, subscription)
+	if err != nil {
+		return fmt.Errorf("error on restoring the subscription approval. name:%s, namespace:%s, approval:%s, error:%w", name, namespace, approval, err)
+	}
+	zap.L().Info("subscription approval restored", zap.String("name", name), zap.String("namespace", namespace), zap.String("approval", string(approval)))
+	return nil
+}
+
+// approves the InstallPlan and waits for the CSV
+func upgradeHop(k8sClient client.Client, subscription *corsosv1alpha1.Subscription, installPlan *corsosv1alpha1.InstallPlan, fromCSV, toCSV string, tc openshiftTY.TimeoutConfig) (*openshiftTY.UpgradeHop, error) {
+	startTime := time.Now()
+	zap.L().Info("upgrading operator", zap.String("subscription", subscription.Name), zap.String("namespace", subscription.Namespace), zap.String("from", fromCSV), zap.String("to", toCSV), zap.String("installPlan", installPlan.Name))
+	err := installPlanAPI.Approve(k8sClient, installPlan)
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitForCSVSucceeded(k8sClient, toCSV, subscription.Namespace, tc)
+	if err != nil {
+		return nil, err
+	}
+
+	hop := &openshiftTY.UpgradeHop{
+		From:        fromCSV,
+		To:          toCSV,
+		InstallPlan: installPlan.Name,
+		TimeTaken:   time.Since(startTime),
+	}
+	zap.L().Info("operator upgraded", zap.String("subscription", subscription.Name), zap.String("namespace", subscription.Namespace), zap.String("from", fromCSV), zap.String("to", toCSV), zap.String("timeTaken", hop.TimeTaken.String()))
+	return hop, nil
+}
+
+// returns the InstallPlan waiting for approval and the next CSV, the current CSV of the subscription.
+// an InstallPlan may include the CSVs of the dependencies, the InstallPlan should include the current CSV
+// returns nil if the subscription at the latest known CSV
+func waitForNextInstallPlan(k8sClient client.Client, name, namespace, installedCSV string, tc openshiftTY.TimeoutConfig) (*corsosv1alpha1.InstallPlan, string, error) {
+	var installPlan *corsosv1alpha1.InstallPlan
+	nextCSV := ""
+	executeFunc := func() (bool, error) {
+		installPlan = nil
+		subscription, err := subscriptionAPI.Get(k8sClient, name, namespace)
+		if err != nil {
+			return false, err
+		}
+		currentCSV := subscription.Status.CurrentCSV
+		if subscription.Status.State == corsosv1alpha1.SubscriptionStateAtLatest && currentCSV == installedCSV {
+			return true, nil
+		}
+		if subscription.Status.InstallPlanRef == nil || currentCSV == "" || currentCSV == installedCSV {
+			return false, nil
+		}
+		ref := subscription.Status.InstallPlanRef
+		_installPlan, err := installPlanAPI.Get(k8sClient, ref.Name, ref.Namespace)
+		if err != nil {
+			return false, utils.IgnoreNotFoundError(err)
+		}
+		if _installPlan.Spec.Approved || !utils.ContainsString(_installPlan.Spec.ClusterServiceVersionNames, currentCSV) {
+			zap.L().Debug("waiting for the next InstallPlan", zap.String("subscription", name), zap.String("namespace", namespace), zap.String("state", string(subscription.Status.State)), zap.String("currentCSV", currentCSV))
+			return false, nil
+		}
+		installPlan = _installPlan
+		nextCSV = currentCSV
+		return true, nil
+	}
+	err := funcUtils.ExecuteWithTimeoutAndContinuesSuccessCount(executeFunc, tc.Timeout, tc.ScanInterval, tc.ExpectedSuccessCount)
+	if err != nil {
+		return nil, "", fmt.Errorf("error on waiting for the next InstallPlan. subscription:%s, namespace:%s, installedCSV:%s, error:%w", name, namespace, installedCSV, err)
+	}
+	return installPlan, nextCSV, nil
+}
+
+// waits for the CSV "Succeeded" phase, on failure returns the recent conditions of the CSV
+func waitForCSVSucceeded(k8sClient client.Client, name, namespace string, tc openshiftTY.TimeoutConfig) error {
+	var csv *corsosv1alpha1.ClusterServiceVersion
+	executeFunc := func() (bool, error) {
+		_csv, err := csvAPI.Get(k8sClient, name, namespace)
+		if err != nil {
+			return false, utils.IgnoreNotFoundError(err)
+		}
+		csv = _csv
+		if csv.Status.Phase == corsosv1alpha1.CSVPhaseFailed {
+			return false, fmt.Errorf("CSV failed. reason:%s, message:%s", csv.Status.Reason, csv.Status.Message)
+		}
+		return csv.Status.Phase == corsosv1alpha1.CSVPhaseSucceeded, nil
+	}
+	err := funcUtils.ExecuteWithTimeoutAndContinuesSuccessCount(executeFunc, tc.Timeout, tc.ScanInterval, tc.ExpectedSuccessCount)
+	if err != nil {
+		conditions := []corsosv1alpha1.ClusterServiceVersionCondition{}
+		var phase corsosv1alpha1.ClusterServiceVersionPhase
+		if csv != nil {
+			phase = csv.Status.Phase
+			conditions = csv.Status.Conditions
+			if len(conditions) > csvConditionsLimit {
+				conditions = conditions[len(conditions)-csvConditionsLimit:]
+			}
+		}
+		return fmt.Errorf("upgrade hop stuck. csv:%s, namespace:%s, phase:%s, conditions:%+v, error:%w", name, namespace, phase, conditions, err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	subscriptionAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/subscription"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testTimeout = openshiftTY.TimeoutConfig{Timeout: time.Millisecond * 300, ScanInterval: time.Millisecond * 10, ExpectedSuccessCount: 1}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := corsosv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func newSubscription(approval corsosv1alpha1.Approval, installedCSV, currentCSV string, state corsosv1alpha1.SubscriptionState, installPlan string) *corsosv1alpha1.Subscription {
+	subscription := &corsosv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-operator", Namespace: "openshift-operators"},
+		Spec:       &corsosv1alpha1.SubscriptionSpec{Package: "my-operator", Channel: "stable", InstallPlanApproval: approval},
+		Status: corsosv1alpha1.SubscriptionStatus{
+			InstalledCSV: installedCSV,
+			CurrentCSV:   currentCSV,
+			State:        state,
+		},
+	}
+	if installPlan != "" {
+		subscription.Status.InstallPlanRef = &corev1.ObjectReference{Name: installPlan, Namespace: "openshift-operators"}
+	}
+	return subscription
+}
+
+func newInstallPlan(name string, csvNames ...string) *corsosv1alpha1.InstallPlan {
+	return &corsosv1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openshift-operators"},
+		Spec:       corsosv1alpha1.InstallPlanSpec{ClusterServiceVersionNames: csvNames, Approval: corsosv1alpha1.ApprovalManual},
+	}
+}
+
+func TestUpgradePathRestoresApproval(t *testing.T) {
+	k8sClient := newFakeClient(t, newSubscription(corsosv1alpha1.ApprovalAutomatic, "my-operator.v1", "my-operator.v1", corsosv1alpha1.SubscriptionStateAtLatest, ""))
+
+	result, err := UpgradePath(k8sClient, openshiftTY.UpgradeRequest{Name: "my-operator", Namespace: "openshift-operators"}, testTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Hops) != 0 || result.InstalledCSV != "my-operator.v1" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	subscription, err := subscriptionAPI.Get(k8sClient, "my-operator", "openshift-operators")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscription.Spec.InstallPlanApproval != corsosv1alpha1.ApprovalAutomatic {
+		t.Errorf("approval not restored: %s", subscription.Spec.InstallPlanApproval)
+	}
+}
+
+func TestUpgradePathRestoresApprovalOnFailure(t *testing.T) {
+	// InstallPlan never includes the current CSV, upgrade times out
+	k8sClient := newFakeClient(t,
+		newSubscription(corsosv1alpha1.ApprovalAutomatic, "my-operator.v1", "my-operator.v2", corsosv1alpha1.SubscriptionStateUpgradePending, "install-abc"),
+		newInstallPlan("install-abc", "dependency.v5"),
+	)
+
+	_, err := UpgradePath(k8sClient, openshiftTY.UpgradeRequest{Name: "my-operator", Namespace: "openshift-operators"}, testTimeout)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	subscription, err := subscriptionAPI.Get(k8sClient, "my-operator", "openshift-operators")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscription.Spec.InstallPlanApproval != corsosv1alpha1.ApprovalAutomatic {
+		t.Errorf("approval not restored: %s", subscription.Spec.InstallPlanApproval)
+	}
+}
+
+func TestWaitForNextInstallPlan(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription *corsosv1alpha1.Subscription
+		installPlan  *corsosv1alpha1.InstallPlan
+		expectedPlan string
+		expectedCSV  string
+		expectError  bool
+	}{
+		{
+			name:         "dependency listed first",
+			subscription: newSubscription(corsosv1alpha1.ApprovalManual, "my-operator.v1", "my-operator.v2", corsosv1alpha1.SubscriptionStateUpgradePending, "install-abc"),
+			installPlan:  newInstallPlan("install-abc", "dependency.v5", "my-operator.v2"),
+			expectedPlan: "install-abc",
+			expectedCSV:  "my-operator.v2",
+		},
+		{
+			name:         "at latest",
+			subscription: newSubscription(corsosv1alpha1.ApprovalManual, "my-operator.v2", "my-operator.v2", corsosv1alpha1.SubscriptionStateAtLatest, "install-abc"),
+			installPlan:  newInstallPlan("install-abc", "my-operator.v2"),
+		},
+		{
+			name:         "current CSV not in the InstallPlan",
+			subscription: newSubscription(corsosv1alpha1.ApprovalManual, "my-operator.v1", "my-operator.v2", corsosv1alpha1.SubscriptionStateUpgradePending, "install-abc"),
+			installPlan:  newInstallPlan("install-abc", "dependency.v5", "my-operator.v1"),
+			expectError:  true,
+		},
+	}
+
+	for _, test := range tests {
+		k8sClient := newFakeClient(t, test.subscription, test.installPlan)
+		installPlan, nextCSV, err := waitForNextInstallPlan(k8sClient, "my-operator", "openshift-operators", test.subscription.Status.InstalledCSV, testTimeout)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		receivedPlan := ""
+		if installPlan != nil {
+			receivedPlan = installPlan.Name
+		}
+		if receivedPlan != test.expectedPlan || nextCSV != test.expectedCSV {
+			t.Errorf("%s: expected:[%s %s], received:[%s %s]", test.name, test.expectedPlan, test.expectedCSV, receivedPlan, nextCSV)
+		}
+	}
+}
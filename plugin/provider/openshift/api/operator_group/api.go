@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	corsosv1 "github.com/operator-framework/api/pkg/operators/v1"
+	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func List(k8sClient client.Client, opts []client.ListOption) (*corsosv1.OperatorGroupList, error) {
+	operatorGroupList := &corsosv1.OperatorGroupList{}
+	err := k8sClient.List(context.Background(), operatorGroupList, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return operatorGroupList, nil
+}
+
+func Get(k8sClient client.Client, name, namespace string) (*corsosv1.OperatorGroup, error) {
+	operatorGroup := &corsosv1.OperatorGroup{}
+	namespacedName := types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}
+	err := k8sClient.Get(context.Background(), namespacedName, operatorGroup)
+	if err != nil {
+		return nil, err
+	}
+	return operatorGroup, nil
+}
+
+func Delete(k8sClient client.Client, operatorGroup *corsosv1.OperatorGroup) error {
+	return utils.IgnoreNotFoundError(k8sClient.Delete(context.Background(), operatorGroup))
+}
+
+func Create(k8sClient client.Client, operatorGroup *corsosv1.OperatorGroup) error {
+	return k8sClient.Create(context.Background(), operatorGroup)
+}
+
+// GlobalOperatorsNamespace holds the operators watching all the namespaces
+const GlobalOperatorsNamespace = "openshift-operators"
+
+// ResolveInstallMode returns the install mode and the target namespaces of the OperatorGroup
+// on empty install mode, AllNamespaces preferred on the "openshift-operators" namespace
+// and OwnNamespace preferred on the other namespaces.
+// supportedModes is not verified, if empty
+func ResolveInstallMode(supportedModes []corsosv1alpha1.InstallMode, namespace string, cfg openshiftTY.OperatorGroupConfig) (corsosv1alpha1.InstallModeType, []string, error) {
+	isSupported := func(mode corsosv1alpha1.InstallModeType) bool {
+		if len(supportedModes) == 0 {
+			return true
+		}
+		for _, supportedMode := range supportedModes {
+			if supportedMode.Type == mode {
+				return supportedMode.Supported
+			}
+		}
+		return false
+	}
+
+	mode := corsosv1alpha1.InstallModeType(cfg.InstallMode)
+	if mode == "" {
+		switch {
+		case len(cfg.TargetNamespaces) == 1 && cfg.TargetNamespaces[0] == namespace:
+			mode = corsosv1alpha1.InstallModeTypeOwnNamespace
+		case len(cfg.TargetNamespaces) == 1:
+			mode = corsosv1alpha1.InstallModeTypeSingleNamespace
+		case len(cfg.TargetNamespaces) > 1:
+			mode = corsosv1alpha1.InstallModeTypeMultiNamespace
+		case namespace == GlobalOperatorsNamespace && isSupported(corsosv1alpha1.InstallModeTypeAllNamespaces):
+			mode = corsosv1alpha1.InstallModeTypeAllNamespaces
+		case isSupported(corsosv1alpha1.InstallModeTypeOwnNamespace):
+			mode = corsosv1alpha1.InstallModeTypeOwnNamespace
+		case isSupported(corsosv1alpha1.InstallModeTypeAllNamespaces):
+			mode = corsosv1alpha1.InstallModeTypeAllNamespaces
+		default:
+			return "", nil, fmt.Errorf("unable to decide the install mode, target namespaces required. supportedModes:%+v", supportedModes)
+		}
+	}
+
+	if !isSupported(mode) {
+		return "", nil, fmt.Errorf("install mode not supported by the operator. installMode:%s, supportedModes:%+v", mode, supportedModes)
+	}
+
+	var targetNamespaces []string
+	switch mode {
+	case corsosv1alpha1.InstallModeTypeAllNamespaces:
+		targetNamespaces = []string{}
+
+	case corsosv1alpha1.InstallModeTypeOwnNamespace:
+		targetNamespaces = []string{namespace}
+
+	case corsosv1alpha1.InstallModeTypeSingleNamespace:
+		if len(cfg.TargetNamespaces) != 1 {
+			return "", nil, fmt.Errorf("install mode %s requires exactly one target namespace. targetNamespaces:%v", mode, cfg.TargetNamespaces)
+		}
+		targetNamespaces = cfg.TargetNamespaces
+
+	case corsosv1alpha1.InstallModeTypeMultiNamespace:
+		if len(cfg.TargetNamespaces) == 0 {
+			return "", nil, fmt.Errorf("install mode %s requires target namespaces", mode)
+		}
+		targetNamespaces = cfg.TargetNamespaces
+
+	default:
+		return "", nil, fmt.Errorf("invalid install mode:%s", mode)
+	}
+	return mode, targetNamespaces, nil
+}
+
+// GetInstallMode returns the install mode of the existing OperatorGroup
+func GetInstallMode(operatorGroup *corsosv1.OperatorGroup) corsosv1alpha1.InstallModeType {
+	targetNamespaces := operatorGroup.Spec.TargetNamespaces
+	switch {
+	case len(targetNamespaces) == 0 && operatorGroup.Spec.Selector == nil:
+		return corsosv1alpha1.InstallModeTypeAllNamespaces
+	case len(targetNamespaces) == 1 && targetNamespaces[0] == operatorGroup.Namespace:
+		return corsosv1alpha1.InstallModeTypeOwnNamespace
+	case len(targetNamespaces) == 1:
+		return corsosv1alpha1.InstallModeTypeSingleNamespace
+	}
+	return corsosv1alpha1.InstallModeTypeMultiNamespace
+}
+
+// Ensure creates the OperatorGroup in the namespace, if not available
+// the existing OperatorGroup is verified against the supported modes and the requested install mode
+func Ensure(k8sClient client.Client, namespace string, supportedModes []corsosv1alpha1.InstallMode, cfg openshiftTY.OperatorGroupConfig) error {
+	operatorGroupList, err := List(k8sClient, []client.ListOption{client.InNamespace(namespace)})
+	if err != nil {
+		return err
+	}
+	isModeRequested := cfg.InstallMode != "" || len(cfg.TargetNamespaces) > 0
+
+	switch len(operatorGroupList.Items) {
+	case 0:
+		mode, targetNamespaces, err := ResolveInstallMode(supportedModes, namespace, cfg)
+		if err != nil {
+			return err
+		}
+		operatorGroup := &corsosv1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      namespace,
+				Namespace: namespace,
+			},
+			Spec: corsosv1.OperatorGroupSpec{TargetNamespaces: targetNamespaces},
+		}
+		err = Create(k8sClient, operatorGroup)
+		if err != nil {
+			return err
+		}
+		zap.L().Info("OperatorGroup created", zap.String("name", operatorGroup.Name), zap.String("namespace", namespace), zap.String("installMode", string(mode)), zap.Strings("targetNamespaces", targetNamespaces))
+		return nil
+
+	case 1:
+		operatorGroup := operatorGroupList.Items[0]
+		existingMode := GetInstallMode(&operatorGroup)
+		if isModeRequested {
+			mode, targetNamespaces, err := ResolveInstallMode(supportedModes, namespace, cfg)
+			if err != nil {
+				return err
+			}
+			if existingMode != mode || (len(targetNamespaces) > 0 && !isSameNamespaces(operatorGroup.Spec.TargetNamespaces, targetNamespaces)) {
+				return fmt.Errorf("existing OperatorGroup mismatch. name:%s, namespace:%s, installMode:%s, targetNamespaces:%v, requestedInstallMode:%s, requestedTargetNamespaces:%v",
+					operatorGroup.Name, namespace, existingMode, operatorGroup.Spec.TargetNamespaces, mode, targetNamespaces)
+			}
+		} else {
+			// the existing OperatorGroup decides the install mode
+			_, _, err = ResolveInstallMode(supportedModes, namespace, openshiftTY.OperatorGroupConfig{InstallMode: string(existingMode), TargetNamespaces: operatorGroup.Spec.TargetNamespaces})
+			if err != nil {
+				return fmt.Errorf("existing OperatorGroup not supported by the operator. name:%s, namespace:%s, error:%w", operatorGroup.Name, namespace, err)
+			}
+		}
+		zap.L().Debug("OperatorGroup exists", zap.String("name", operatorGroup.Name), zap.String("namespace", namespace), zap.String("installMode", string(existingMode)))
+		return nil
+
+	default:
+		names := []string{}
+		for _, operatorGroup := range operatorGroupList.Items {
+			names = append(names, operatorGroup.Name)
+		}
+		return fmt.Errorf("multiple OperatorGroups found in the namespace, OLM does not support it. namespace:%s, operatorGroups:%v", namespace, names)
+	}
+}
+
+func isSameNamespaces(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
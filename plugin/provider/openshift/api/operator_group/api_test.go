@@ -0,0 +1,59 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestResolveInstallMode(t *testing.T) {
+	supported := func(modes ...corsosv1alpha1.InstallModeType) []corsosv1alpha1.InstallMode {
+		installModes := []corsosv1alpha1.InstallMode{}
+		for _, mode := range modes {
+			installModes = append(installModes, corsosv1alpha1.InstallMode{Type: mode, Supported: true})
+		}
+		return installModes
+	}
+	all := corsosv1alpha1.InstallModeTypeAllNamespaces
+	own := corsosv1alpha1.InstallModeTypeOwnNamespace
+	single := corsosv1alpha1.InstallModeTypeSingleNamespace
+
+	tests := []struct {
+		name            string
+		supportedModes  []corsosv1alpha1.InstallMode
+		namespace       string
+		cfg             openshiftTY.OperatorGroupConfig
+		expectedMode    corsosv1alpha1.InstallModeType
+		expectedTargets []string
+		expectError     bool
+	}{
+		{name: "global namespace", supportedModes: supported(all, own), namespace: GlobalOperatorsNamespace, expectedMode: all, expectedTargets: []string{}},
+		{name: "global namespace own only", supportedModes: supported(own), namespace: GlobalOperatorsNamespace, expectedMode: own, expectedTargets: []string{GlobalOperatorsNamespace}},
+		{name: "other namespace", supportedModes: supported(all, own), namespace: "team-a", expectedMode: own, expectedTargets: []string{"team-a"}},
+		{name: "other namespace all only", supportedModes: supported(all), namespace: "team-a", expectedMode: all, expectedTargets: []string{}},
+		{name: "unknown supported modes", namespace: "team-a", expectedMode: own, expectedTargets: []string{"team-a"}},
+		{name: "single target", supportedModes: supported(all, own, single), namespace: "team-a", cfg: openshiftTY.OperatorGroupConfig{TargetNamespaces: []string{"team-b"}}, expectedMode: single, expectedTargets: []string{"team-b"}},
+		{name: "explicit mode", supportedModes: supported(all, own), namespace: "team-a", cfg: openshiftTY.OperatorGroupConfig{InstallMode: string(all)}, expectedMode: all, expectedTargets: []string{}},
+		{name: "explicit unsupported mode", supportedModes: supported(all), namespace: "team-a", cfg: openshiftTY.OperatorGroupConfig{InstallMode: string(own)}, expectError: true},
+		{name: "nothing supported", supportedModes: supported(single), namespace: "team-a", expectError: true},
+	}
+
+	for _, test := range tests {
+		mode, targetNamespaces, err := ResolveInstallMode(test.supportedModes, test.namespace, test.cfg)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if mode != test.expectedMode || !reflect.DeepEqual(targetNamespaces, test.expectedTargets) {
+			t.Errorf("%s: expected:[%s %v], received:[%s %v]", test.name, test.expectedMode, test.expectedTargets, mode, targetNamespaces)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PackageManifest is served by the OLM package server, the type is not registered in the scheme
+var packageManifestGVK = schema.GroupVersionKind{Group: "packages.operators.coreos.com", Version: "v1", Kind: "PackageManifestList"}
+
+// catalog source label of the PackageManifest
+const catalogSourceLabel = "catalog"
+
+// Get returns the PackageManifest of the package from the catalog source
+func Get(k8sClient client.Client, packageName, catalogSource, catalogSourceNamespace string) (*unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(packageManifestGVK)
+	opts := []client.ListOption{client.InNamespace(catalogSourceNamespace)}
+	if catalogSource != "" {
+		opts = append(opts, client.MatchingLabels{catalogSourceLabel: catalogSource})
+	}
+	err := k8sClient.List(context.Background(), list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for index := range list.Items {
+		if list.Items[index].GetName() == packageName {
+			return &list.Items[index], nil
+		}
+	}
+	return nil, fmt.Errorf("package not found. package:%s, catalogSource:%s, namespace:%s", packageName, catalogSource, catalogSourceNamespace)
+}
+
+// GetInstallModes returns the install modes of the channel head CSV
+// on empty channel, the default channel of the package is used
+func GetInstallModes(k8sClient client.Client, packageName, channel, catalogSource, catalogSourceNamespace string) ([]corsosv1alpha1.InstallMode, error) {
+	packageManifest, err := Get(k8sClient, packageName, catalogSource, catalogSourceNamespace)
+	if err != nil {
+		return nil, err
+	}
+	if channel == "" {
+		channel, _, _ = unstructured.NestedString(packageManifest.Object, "status", "defaultChannel")
+	}
+	channels, _, err := unstructured.NestedSlice(packageManifest.Object, "status", "channels")
+	if err != nil {
+		return nil, err
+	}
+	for _, rawChannel := range channels {
+		channelMap, ok := rawChannel.(map[string]interface{})
+		if !ok || channelMap["name"] != channel {
+			continue
+		}
+		rawModes, _, err := unstructured.NestedSlice(channelMap, "currentCSVDesc", "installModes")
+		if err != nil {
+			return nil, err
+		}
+		installModes := make([]corsosv1alpha1.InstallMode, 0)
+		for _, rawMode := range rawModes {
+			modeMap, ok := rawMode.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			installMode := corsosv1alpha1.InstallMode{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(modeMap, &installMode)
+			if err != nil {
+				return nil, err
+			}
+			installModes = append(installModes, installMode)
+		}
+		return installModes, nil
+	}
+	return nil, fmt.Errorf("channel not found. package:%s, channel:%s", packageName, channel)
+}
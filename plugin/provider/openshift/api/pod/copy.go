@@ -0,0 +1,252 @@
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// CopyToPod copies a local file or directory to the container, same as "oc cp"
+// the container should have the tar binary. file permissions and modification time are preserved
+// returns the number of copied files
+func CopyToPod(ctx context.Context, clientSet kubernetes.Interface, restConfig *rest.Config, pod *corev1.Pod, container, srcPath, destPath string) (int, error) {
+	if srcPath == "" || destPath == "" {
+		return 0, errors.New("source and destination can not be empty")
+	}
+	srcPath = filepath.Clean(srcPath)
+	destPath = path.Clean(destPath)
+	if _, err := os.Stat(srcPath); err != nil {
+		return 0, err
+	}
+
+	stderr := &bytes.Buffer{}
+	command := []string{"tar", "-xpf", "-", "-C", path.Dir(destPath)}
+	streamFunc := func(reader io.Reader) error {
+		return Stream(ctx, clientSet, restConfig, pod, container, command, reader, io.Discard, stderr)
+	}
+	filesCount, err := streamTar(srcPath, path.Base(destPath), streamFunc)
+	if err != nil {
+		return 0, fmt.Errorf("error on copying to the pod. pod:%s, namespace:%s, stderr:%s, error:%w", pod.Name, pod.Namespace, stderr.String(), err)
+	}
+	return filesCount, nil
+}
+
+type tarResult struct {
+	count int
+	err   error
+}
+
+// writes the source as tar stream into the stream function, returns the number of files.
+// the stream does not report the writer error, an incomplete archive may be extracted without error,
+// hence the writer error returned, if the stream completed without error
+func streamTar(srcPath, targetName string, streamFunc func(reader io.Reader) error) (int, error) {
+	reader, writer := io.Pipe()
+	resultCh := make(chan tarResult, 1)
+	go func() {
+		count, err := writeTar(writer, srcPath, targetName)
+		writer.CloseWithError(err)
+		resultCh <- tarResult{count: count, err: err}
+	}()
+
+	err := streamFunc(reader)
+	// unblocks the writer, if the stream terminated
+	reader.Close()
+	result := <-resultCh
+	if err != nil {
+		return 0, err
+	}
+	if result.err != nil {
+		return 0, fmt.Errorf("error on writing the archive. source:%s, error:%w", srcPath, result.err)
+	}
+	return result.count, nil
+}
+
+// CopyFromPod copies a file or directory from the container to the local path, same as "oc cp"
+// the container should have the tar binary. file permissions and modification time are preserved
+// returns the number of copied files
+func CopyFromPod(ctx context.Context, clientSet kubernetes.Interface, restConfig *rest.Config, pod *corev1.Pod, container, srcPath, destPath string) (int, error) {
+	if srcPath == "" || destPath == "" {
+		return 0, errors.New("source and destination can not be empty")
+	}
+	srcPath = path.Clean(srcPath)
+	destPath = getLocalDestPath(srcPath, filepath.Clean(destPath))
+
+	reader, writer := io.Pipe()
+	stderr := &bytes.Buffer{}
+	go func() {
+		command := []string{"tar", "-cf", "-", "-C", path.Dir(srcPath), path.Base(srcPath)}
+		err := Stream(ctx, clientSet, restConfig, pod, container, command, nil, writer, stderr)
+		if err != nil {
+			err = fmt.Errorf("error on copying from the pod. pod:%s, namespace:%s, stderr:%s, error:%w", pod.Name, pod.Namespace, stderr.String(), err)
+		}
+		writer.CloseWithError(err)
+	}()
+
+	filesCount, err := readTar(reader, path.Base(srcPath), destPath)
+	reader.Close()
+	return filesCount, err
+}
+
+// existing local directory receives the source inside it, same as "oc cp"
+func getLocalDestPath(srcPath, destPath string) string {
+	info, err := os.Stat(destPath)
+	if err != nil || !info.IsDir() {
+		return destPath
+	}
+	baseName := path.Base(srcPath)
+	if baseName == "/" || baseName == "." {
+		return destPath
+	}
+	return filepath.Join(destPath, baseName)
+}
+
+// writes the source into the tar stream, the source base name replaced with the target name
+func writeTar(writer io.Writer, srcPath, targetName string) (int, error) {
+	tarWriter := tar.NewWriter(writer)
+	filesCount := 0
+	err := filepath.Walk(srcPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcPath, filePath)
+		if err != nil {
+			return err
+		}
+		name := path.Join(targetName, filepath.ToSlash(relPath))
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err = os.Readlink(filePath)
+			if err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tarWriter, file)
+		if err != nil {
+			return err
+		}
+		filesCount++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return filesCount, tarWriter.Close()
+}
+
+// extracts the tar stream into the destination path, the source base name replaced with the destination
+func readTar(reader io.Reader, srcName, destPath string) (int, error) {
+	tarReader := tar.NewReader(reader)
+	filesCount := 0
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return filesCount, nil
+		}
+		if err != nil {
+			return filesCount, err
+		}
+
+		// replace the source name with the destination path
+		name := path.Clean(header.Name)
+		relPath := strings.TrimPrefix(strings.TrimPrefix(name, srcName), "/")
+		if name != srcName && !strings.HasPrefix(name, srcName+"/") {
+			zap.L().Warn("skipped an unexpected entry from the archive", zap.String("name", header.Name))
+			continue
+		}
+		targetPath := filepath.Join(destPath, filepath.FromSlash(relPath))
+		if !isWithin(destPath, targetPath) {
+			return filesCount, fmt.Errorf("archive entry is outside of the destination. name:%s", header.Name)
+		}
+
+		mode := os.FileMode(header.Mode).Perm()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(targetPath, mode)
+			if err != nil {
+				return filesCount, err
+			}
+
+		case tar.TypeReg:
+			err = os.MkdirAll(filepath.Dir(targetPath), os.ModePerm)
+			if err != nil {
+				return filesCount, err
+			}
+			file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+			if err != nil {
+				return filesCount, err
+			}
+			_, err = io.Copy(file, tarReader)
+			file.Close()
+			if err != nil {
+				return filesCount, err
+			}
+			filesCount++
+
+		case tar.TypeSymlink:
+			linkPath := filepath.Join(filepath.Dir(targetPath), header.Linkname)
+			if filepath.IsAbs(header.Linkname) || !isWithin(destPath, linkPath) {
+				zap.L().Warn("skipped a symlink points outside of the destination", zap.String("name", header.Name), zap.String("link", header.Linkname))
+				continue
+			}
+			_ = os.Remove(targetPath)
+			err = os.Symlink(header.Linkname, targetPath)
+			if err != nil {
+				return filesCount, err
+			}
+			continue
+
+		default:
+			zap.L().Debug("skipped an unsupported entry from the archive", zap.String("name", header.Name), zap.Any("type", header.Typeflag))
+			continue
+		}
+
+		// umask may change the permissions on create
+		err = os.Chmod(targetPath, mode)
+		if err != nil {
+			return filesCount, err
+		}
+		err = os.Chtimes(targetPath, header.ModTime, header.ModTime)
+		if err != nil {
+			return filesCount, err
+		}
+	}
+}
+
+func isWithin(basePath, targetPath string) bool {
+	relPath, err := filepath.Rel(basePath, targetPath)
+	return err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetLocalDestPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	existingFile := filepath.Join(tmpDir, "file.txt")
+	if err := os.WriteFile(existingFile, []byte("data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		srcPath  string
+		destPath string
+		expected string
+	}{
+		{name: "existing directory", srcPath: "/var/log/app", destPath: tmpDir, expected: filepath.Join(tmpDir, "app")},
+		{name: "existing file", srcPath: "/var/log/app.log", destPath: existingFile, expected: existingFile},
+		{name: "new path", srcPath: "/var/log/app", destPath: filepath.Join(tmpDir, "logs"), expected: filepath.Join(tmpDir, "logs")},
+		{name: "root source", srcPath: "/", destPath: tmpDir, expected: tmpDir},
+	}
+
+	for _, test := range tests {
+		received := getLocalDestPath(test.srcPath, test.destPath)
+		if received != test.expected {
+			t.Errorf("%s: expected:%s, received:%s", test.name, test.expected, received)
+		}
+	}
+}
+
+func TestTarIntoExistingDirectory(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "config")
+	if err := os.MkdirAll(filepath.Join(srcDir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "sub", "app.yaml"), []byte("key: value"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := &bytes.Buffer{}
+	_, err := writeTar(archive, srcDir, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := t.TempDir()
+	count, err := readTar(archive, "config", getLocalDestPath("/etc/config", destDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 file, received:%d", count)
+	}
+
+	data, err := os.ReadFile(filepath.Join(destDir, "config", "sub", "app.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "key: value" {
+		t.Errorf("unexpected content: %s", string(data))
+	}
+}
+
+func TestStreamTarWriterError(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "data")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "a.txt"), []byte("a"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	// sockets are not supported on the archive, fails in the middle of the walk
+	listener, err := net.Listen("unix", filepath.Join(srcDir, "b.sock"))
+	if err != nil {
+		t.Skipf("unix socket not supported: %v", err)
+	}
+	defer listener.Close()
+
+	// the remote tar extracts the partial archive without error
+	received := &bytes.Buffer{}
+	streamFunc := func(reader io.Reader) error {
+		_, _ = io.Copy(received, reader)
+		return nil
+	}
+	count, err := streamTar(srcDir, "data", streamFunc)
+	if err == nil {
+		t.Fatalf("expected the writer error, received count:%d", count)
+	}
+	if count != 0 {
+		t.Errorf("expected zero count on error, received:%d", count)
+	}
+}
+
+func TestStreamTar(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "data")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(name), 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	destDir := t.TempDir()
+	streamFunc := func(reader io.Reader) error {
+		_, err := readTar(reader, "data", filepath.Join(destDir, "data"))
+		return err
+	}
+	count, err := streamTar(srcDir, "data", streamFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 files, received:%d", count)
+	}
+}
+
+func TestStreamTarStreamError(t *testing.T) {
+	srcDir := t.TempDir()
+	streamErr := errors.New("stream failed")
+	_, err := streamTar(srcDir, "data", func(reader io.Reader) error { return streamErr })
+	if !errors.Is(err, streamErr) {
+		t.Errorf("expected the stream error, received:%v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// Exec runs the command in the container and returns the stdout, stderr and exit code
+func Exec(ctx context.Context, clientSet kubernetes.Interface, restConfig *rest.Config, pod *corev1.Pod, container string, command []string, stdin string) (*openshiftTY.ExecResult, error) {
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	var stdinReader io.Reader
+	if stdin != "" {
+		stdinReader = strings.NewReader(stdin)
+	}
+
+	result := &openshiftTY.ExecResult{
+		Pod:       pod.Name,
+		Namespace: pod.Namespace,
+		Container: container,
+	}
+	err := Stream(ctx, clientSet, restConfig, pod, container, command, stdinReader, stdout, stderr)
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	if err != nil {
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitStatus()
+			return result, nil
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// Stream runs the command in the container with the supplied streams
+// stdin is optional, returns utilexec.ExitError on non-zero exit code
+func Stream(ctx context.Context, clientSet kubernetes.Interface, restConfig *rest.Config, pod *corev1.Pod, container string, command []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if clientSet == nil || restConfig == nil {
+		return errors.New("cluster client set and rest config can not be empty")
+	}
+	if len(command) == 0 {
+		return errors.New("command can not be empty")
+	}
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	execOptions := &corev1.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdin:     stdin != nil,
+		Stdout:    stdout != nil,
+		Stderr:    stderr != nil,
+	}
+	req := clientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(execOptions, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
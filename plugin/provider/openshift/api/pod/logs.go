@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// StreamLogs writes the logs of the container into the writer
+// on follow, the logs are written till the context done or the container terminated
+func StreamLogs(ctx context.Context, clientSet kubernetes.Interface, pod *corev1.Pod, opts *corev1.PodLogOptions, writer io.Writer) error {
+	if clientSet == nil {
+		return errors.New("cluster client set can not be empty")
+	}
+	stream, err := clientSet.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	_, err = io.Copy(writer, stream)
+	if err != nil && opts.Follow && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// follow reached the timeout
+		return nil
+	}
+	return err
+}
@@ -1,10 +1,12 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,91 +15,214 @@ import (
 	k8s "github.com/jkandasa/autoeasy/plugin/provider/openshift/client"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func PortForward(restConfig *rest.Config, pfCfg openshiftTY.PortForwardRequest) (func(), error) {
+	closeFunc, _, err := Start(restConfig, pfCfg)
+	return closeFunc, err
+}
+
+// Start opens a port forward and returns the close function and the forwarded ports
+// random local ports are resolved on the returned ports
+func Start(restConfig *rest.Config, pfCfg openshiftTY.PortForwardRequest) (func(), []openshiftTY.ForwardedPort, error) {
 	if restConfig == nil {
-		return nil, errors.New("cluster rest config can not be empty")
+		return nil, nil, errors.New("cluster rest config can not be empty")
 	}
 
 	if pfCfg.Namespace == "" {
-		return nil, fmt.Errorf("namespace can not be empty. namespace:%s, pod:%s", pfCfg.Namespace, pfCfg.Pod)
+		return nil, nil, fmt.Errorf("namespace can not be empty. namespace:%s, pod:%s", pfCfg.Namespace, pfCfg.Pod)
+	}
+
+	if pfCfg.Pod == "" && pfCfg.Deployment == "" && pfCfg.Service == "" {
+		return nil, nil, errors.New("pod, deployment and service can not be empty")
+	}
+
+	// load defaults
+	if pfCfg.Streams == nil {
+		pfCfg.Streams = iostreamUtils.GetLogWriter()
 	}
 
-	if pfCfg.Pod == "" && pfCfg.Deployment == "" {
-		return nil, errors.New("both pod and deployment can not be empty")
+	if len(pfCfg.Addresses) == 0 {
+		pfCfg.Addresses = []string{"127.0.0.1"}
+	}
+	if len(pfCfg.Ports) == 0 {
+		pfCfg.Ports = []string{"8080:8080"} // localPort:targetPort
 	}
 
 	// get pod name
-	if pfCfg.Pod == "" && pfCfg.Deployment != "" {
+	if pfCfg.Pod == "" {
 		k8sClient, err := k8s.NewClientFromRestConfig(restConfig)
 		if err != nil {
 			zap.L().Error("error on getting k8s client", zap.Error(err))
-			return nil, err
+			return nil, nil, err
 		}
-		pods, err := deploymentAPI.ListRunningPods(k8sClient, pfCfg.Deployment, pfCfg.Namespace)
+		err = updatePod(k8sClient, &pfCfg)
 		if err != nil {
-			zap.L().Error("error on getting deployment", zap.Any("config", pfCfg), zap.Error(err))
-			return nil, err
-		}
-
-		if len(pods) == 0 {
-			return nil, fmt.Errorf("unable to get a running pod. deployment:%s, namespace:%s", pfCfg.Deployment, pfCfg.Namespace)
+			zap.L().Error("error on selecting a pod", zap.Any("config", pfCfg), zap.Error(err))
+			return nil, nil, err
 		}
-
-		// update pod details
-		_pod := pods[0]
-		pfCfg.Pod = _pod.GetName()
-		zap.L().Debug("selected a pod", zap.String("name", _pod.GetName()), zap.String("namespace", _pod.GetNamespace()))
 	}
 
 	// stopCh control the port forwarding lifecycle. When it gets closed the port forward will terminate
 	stopCh := make(chan struct{}, 1)
 	// readyCh communicate when the port forward is ready to get traffic
 	readyCh := make(chan struct{})
+	// errCh communicate the failure before the port forward gets ready
+	errCh := make(chan error, 1)
 
-	// load defaults
-	if pfCfg.Streams == nil {
-		pfCfg.Streams = iostreamUtils.GetLogWriter()
-	}
-
-	if len(pfCfg.Addresses) == 0 {
-		pfCfg.Addresses = []string{"127.0.0.1"}
-	}
-	if len(pfCfg.Ports) == 0 {
-		pfCfg.Addresses = []string{"8080:8080"} // localPort:targetPort
+	fw, err := newPortForwarder(restConfig, pfCfg, stopCh, readyCh)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	go func() {
-		err := portForwardToPodOrDeployment(restConfig, pfCfg, stopCh, readyCh)
+		err := fw.ForwardPorts()
 		if err != nil {
 			zap.L().Error("error on port forward", zap.Any("config", pfCfg), zap.Error(err))
+			errCh <- err
 		}
 	}()
 
 	select {
 	case <-readyCh:
-		zap.L().Info("port forward ready", zap.Any("config", pfCfg))
 		break
 
+	case err := <-errCh:
+		return nil, nil, err
+
 	case <-time.After(10 * time.Second):
+		close(stopCh)
 		zap.L().Error("port forward reached timeout", zap.Any("config", pfCfg))
-		return nil, errors.New("port forward reached timeout")
+		return nil, nil, errors.New("port forward reached timeout")
 	}
 
+	forwardedPorts, err := fw.GetPorts()
+	if err != nil {
+		close(stopCh)
+		return nil, nil, err
+	}
+	ports := make([]openshiftTY.ForwardedPort, 0)
+	for _, port := range forwardedPorts {
+		ports = append(ports, openshiftTY.ForwardedPort{Local: port.Local, Remote: port.Remote})
+	}
+	zap.L().Info("port forward ready", zap.Any("config", pfCfg), zap.Any("ports", ports))
+
 	closeFunc := func() {
 		close(stopCh)
 	}
 
-	return closeFunc, nil
+	return closeFunc, ports, nil
+}
+
+// selects a running pod of the deployment or service
+// on service, the service ports are translated to the target ports of the pod
+func updatePod(k8sClient client.Client, pfCfg *openshiftTY.PortForwardRequest) error {
+	var pods []corev1.Pod
+	if pfCfg.Deployment != "" {
+		deploymentPods, err := deploymentAPI.ListRunningPods(k8sClient, pfCfg.Deployment, pfCfg.Namespace)
+		if err != nil {
+			return err
+		}
+		pods = deploymentPods
+	} else {
+		service := &corev1.Service{}
+		err := k8sClient.Get(context.Background(), types.NamespacedName{Name: pfCfg.Service, Namespace: pfCfg.Namespace}, service)
+		if err != nil {
+			return err
+		}
+		if len(service.Spec.Selector) == 0 {
+			return fmt.Errorf("service does not have a selector. service:%s, namespace:%s", pfCfg.Service, pfCfg.Namespace)
+		}
+		podList := &corev1.PodList{}
+		err = k8sClient.List(context.Background(), podList, client.InNamespace(pfCfg.Namespace), client.MatchingLabels(service.Spec.Selector))
+		if err != nil {
+			return err
+		}
+		for _, pod := range podList.Items {
+			if pod.Status.Phase == corev1.PodRunning {
+				pods = append(pods, pod)
+			}
+		}
+		if len(pods) > 0 {
+			ports, err := translateServicePorts(service, &pods[0], pfCfg.Ports)
+			if err != nil {
+				return err
+			}
+			pfCfg.Ports = ports
+		}
+	}
+
+	if len(pods) == 0 {
+		return fmt.Errorf("unable to get a running pod. deployment:%s, service:%s, namespace:%s", pfCfg.Deployment, pfCfg.Service, pfCfg.Namespace)
+	}
+
+	// update pod details
+	_pod := pods[0]
+	pfCfg.Pod = _pod.GetName()
+	zap.L().Debug("selected a pod", zap.String("name", _pod.GetName()), zap.String("namespace", _pod.GetNamespace()))
+	return nil
 }
 
-func portForwardToPodOrDeployment(restCfg *rest.Config, pfConfig openshiftTY.PortForwardRequest, stopCh <-chan struct{}, readyCh chan struct{}) error {
+// translates the service ports to the container ports, the local port remains the same
+func translateServicePorts(service *corev1.Service, pod *corev1.Pod, ports []string) ([]string, error) {
+	translated := make([]string, 0)
+	for _, port := range ports {
+		localPort, remotePort := "", port
+		if index := strings.LastIndex(port, ":"); index != -1 {
+			localPort, remotePort = port[:index+1], port[index+1:]
+		}
+		servicePort, err := strconv.Atoi(remotePort)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port:%s", port)
+		}
+
+		targetPort := servicePort
+		for _, sp := range service.Spec.Ports {
+			if int(sp.Port) != servicePort {
+				continue
+			}
+			switch {
+			case sp.TargetPort.Type == intstr.String:
+				containerPort, found := getContainerPort(pod, sp.TargetPort.StrVal)
+				if !found {
+					return nil, fmt.Errorf("named port not found in the pod. port:%s, pod:%s", sp.TargetPort.StrVal, pod.Name)
+				}
+				targetPort = int(containerPort)
+			case sp.TargetPort.IntVal != 0:
+				targetPort = int(sp.TargetPort.IntVal)
+			}
+			break
+		}
+		// keeps the service port as local port, if not specified
+		if localPort == "" {
+			localPort = fmt.Sprintf("%d:", servicePort)
+		}
+		translated = append(translated, fmt.Sprintf("%s%d", localPort, targetPort))
+	}
+	return translated, nil
+}
+
+func getContainerPort(pod *corev1.Pod, name string) (int32, bool) {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == name {
+				return port.ContainerPort, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func newPortForwarder(restCfg *rest.Config, pfConfig openshiftTY.PortForwardRequest, stopCh <-chan struct{}, readyCh chan struct{}) (*portforward.PortForwarder, error) {
 	if pfConfig.Pod == "" {
-		return errors.New("pod name can not be empty")
+		return nil, errors.New("pod name can not be empty")
 	}
 
 	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/portforward", pfConfig.Namespace, pfConfig.Pod)
@@ -105,13 +230,9 @@ func portForwardToPodOrDeployment(restCfg *rest.Config, pfConfig openshiftTY.Por
 
 	transport, upgrader, err := spdy.RoundTripperFor(restCfg)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, &url.URL{Scheme: "https", Path: path, Host: hostIP})
-	fw, err := portforward.NewOnAddresses(dialer, pfConfig.Addresses, pfConfig.Ports, stopCh, readyCh, pfConfig.Streams.Out, pfConfig.Streams.ErrOut)
-	if err != nil {
-		return err
-	}
-	return fw.ForwardPorts()
+	return portforward.NewOnAddresses(dialer, pfConfig.Addresses, pfConfig.Ports, stopCh, readyCh, pfConfig.Streams.Out, pfConfig.Streams.ErrOut)
 }
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jkandasa/autoeasy/pkg/json"
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetResourceInterface returns the dynamic resource interface of the given apiVersion and kind
+// the resource details are resolved with the RESTMapper of the client
+func GetResourceInterface(k8sClient client.Client, dynamicClient dynamic.Interface, apiVersion, kind, namespace string) (dynamic.ResourceInterface, error) {
+	if dynamicClient == nil {
+		return nil, errors.New("dynamic client not loaded")
+	}
+	if apiVersion == "" || kind == "" {
+		return nil, fmt.Errorf("apiVersion and kind can not be empty. apiVersion:%s, kind:%s", apiVersion, kind)
+	}
+
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := k8sClient.RESTMapper().RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return dynamicClient.Resource(mapping.Resource).Namespace(namespace), nil
+	}
+	return dynamicClient.Resource(mapping.Resource), nil
+}
+
+func List(k8sClient client.Client, dynamicClient dynamic.Interface, apiVersion, kind, namespace string, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	resourceClient, err := GetResourceInterface(k8sClient, dynamicClient, apiVersion, kind, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return resourceClient.List(context.Background(), opts)
+}
+
+func Get(k8sClient client.Client, dynamicClient dynamic.Interface, apiVersion, kind, name, namespace string) (*unstructured.Unstructured, error) {
+	resourceClient, err := GetResourceInterface(k8sClient, dynamicClient, apiVersion, kind, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return resourceClient.Get(context.Background(), name, metav1.GetOptions{})
+}
+
+func Delete(k8sClient client.Client, dynamicClient dynamic.Interface, apiVersion, kind, name, namespace string) error {
+	resourceClient, err := GetResourceInterface(k8sClient, dynamicClient, apiVersion, kind, namespace)
+	if err != nil {
+		return err
+	}
+	propagationPolicy := metav1.DeletePropagationBackground
+	err = resourceClient.Delete(context.Background(), name, metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	return utils.IgnoreNotFoundError(err)
+}
+
+func Create(k8sClient client.Client, dynamicClient dynamic.Interface, resource *unstructured.Unstructured) error {
+	resourceClient, err := GetResourceInterface(k8sClient, dynamicClient, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace())
+	if err != nil {
+		return err
+	}
+	_, err = resourceClient.Create(context.Background(), resource, metav1.CreateOptions{})
+	return err
+}
+
+func CreateWithMap(k8sClient client.Client, dynamicClient dynamic.Interface, cfg map[string]interface{}) error {
+	resource, err := ToUnstructured(cfg)
+	if err != nil {
+		return err
+	}
+	return Create(k8sClient, dynamicClient, resource)
+}
+
+// Apply updates the resource with server-side apply
+func Apply(k8sClient client.Client, dynamicClient dynamic.Interface, resource *unstructured.Unstructured, fieldManager string, force bool) (*unstructured.Unstructured, error) {
+	resourceClient, err := GetResourceInterface(k8sClient, dynamicClient, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	if fieldManager == "" {
+		fieldManager = openshiftTY.DefaultFieldManager
+	}
+	return resourceClient.Apply(context.Background(), resource.GetName(), resource, metav1.ApplyOptions{FieldManager: fieldManager, Force: force})
+}
+
+// Patch updates the resource with the supplied patch
+// supported patch types: merge, json and strategic
+func Patch(k8sClient client.Client, dynamicClient dynamic.Interface, ref openshiftTY.ResourceRef, patchType string, data []byte, fieldManager string) (*unstructured.Unstructured, error) {
+	var k8sPatchType types.PatchType
+	switch patchType {
+	case openshiftTY.PatchTypeMerge, "":
+		k8sPatchType = types.MergePatchType
+	case openshiftTY.PatchTypeJSON:
+		k8sPatchType = types.JSONPatchType
+	case openshiftTY.PatchTypeStrategic:
+		k8sPatchType = types.StrategicMergePatchType
+	default:
+		return nil, fmt.Errorf("unsupported patch type:%s", patchType)
+	}
+
+	resourceClient, err := GetResourceInterface(k8sClient, dynamicClient, ref.APIVersion, ref.Kind, ref.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if fieldManager == "" {
+		fieldManager = openshiftTY.DefaultFieldManager
+	}
+	return resourceClient.Patch(context.Background(), ref.Name, k8sPatchType, data, metav1.PatchOptions{FieldManager: fieldManager})
+}
+
+// ToUnstructured converts the map to unstructured resource
+// the map is converted via json to keep only json compatible values
+func ToUnstructured(cfg map[string]interface{}) (*unstructured.Unstructured, error) {
+	jsonBytes, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	resource := &unstructured.Unstructured{}
+	err = resource.UnmarshalJSON(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// wait for resources ready state
+func WaitForReady(k8sClient client.Client, dynamicClient dynamic.Interface, items []openshiftTY.ResourceRef, tc openshiftTY.TimeoutConfig) error {
+	executeFunc := func() (bool, error) {
+		return isReady(k8sClient, dynamicClient, items)
+	}
+	return funcUtils.ExecuteWithTimeoutAndContinuesSuccessCount(executeFunc, tc.Timeout, tc.ScanInterval, tc.ExpectedSuccessCount)
+}
+
+// wait for resources deletion
+func WaitForDeletion(k8sClient client.Client, dynamicClient dynamic.Interface, items []openshiftTY.ResourceRef, tc openshiftTY.TimeoutConfig) error {
+	executeFunc := func() (bool, error) {
+		return isAbsent(k8sClient, dynamicClient, items)
+	}
+	return funcUtils.ExecuteWithTimeoutAndContinuesSuccessCount(executeFunc, tc.Timeout, tc.ScanInterval, tc.ExpectedSuccessCount)
+}
+
+func isReady(k8sClient client.Client, dynamicClient dynamic.Interface, items []openshiftTY.ResourceRef) (bool, error) {
+	notReady := []string{}
+	for _, item := range items {
+		resource, err := Get(k8sClient, dynamicClient, item.APIVersion, item.Kind, item.Name, item.Namespace)
+		if err != nil {
+			if utils.IgnoreNotFoundError(err) != nil {
+				return false, err
+			}
+			notReady = append(notReady, refString(item))
+			continue
+		}
+		if !IsResourceReady(resource) {
+			notReady = append(notReady, refString(item))
+		}
+	}
+
+	if len(notReady) == 0 {
+		zap.L().Debug("resources are ready", zap.Any("resources", items))
+		return true, nil
+	}
+	zap.L().Debug("waiting for resources ready state", zap.Any("notReady", notReady))
+	return false, nil
+}
+
+func isAbsent(k8sClient client.Client, dynamicClient dynamic.Interface, items []openshiftTY.ResourceRef) (bool, error) {
+	available := []string{}
+	for _, item := range items {
+		_, err := Get(k8sClient, dynamicClient, item.APIVersion, item.Kind, item.Name, item.Namespace)
+		if err != nil {
+			if utils.IgnoreNotFoundError(err) != nil {
+				return false, err
+			}
+			continue
+		}
+		available = append(available, refString(item))
+	}
+
+	if len(available) == 0 {
+		zap.L().Debug("resources are absent", zap.Any("resources", items))
+		return true, nil
+	}
+	zap.L().Debug("waiting for resources to be removed", zap.Any("stillPresent", available))
+	return false, nil
+}
+
+// IsResourceReady returns the ready status of a resource
+// checks the "Ready" or "Available" status condition, if available
+// resources without those conditions are considered ready, once they exist
+func IsResourceReady(resource *unstructured.Unstructured) bool {
+	conditions, found, err := unstructured.NestedSlice(resource.Object, "status", "conditions")
+	if err != nil || !found {
+		return true
+	}
+	for _, rawCondition := range conditions {
+		condition, ok := rawCondition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType := fmt.Sprintf("%v", condition["type"])
+		if conditionType != "Ready" && conditionType != "Available" {
+			continue
+		}
+		if !strings.EqualFold(fmt.Sprintf("%v", condition["status"]), string(metav1.ConditionTrue)) {
+			return false
+		}
+	}
+	return true
+}
+
+func refString(item openshiftTY.ResourceRef) string {
+	if item.Namespace == "" {
+		return fmt.Sprintf("%s/%s", item.Kind, item.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", item.Kind, item.Namespace, item.Name)
+}
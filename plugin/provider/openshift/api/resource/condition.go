@@ -0,0 +1,233 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// supported compare operators with two characters, ">" and "<" are handled on the parser
+var compareOperators = []string{"==", "!=", ">=", "<="}
+
+// wait for the conditions on the resources
+func WaitForCondition(k8sClient client.Client, dynamicClient dynamic.Interface, items []openshiftTY.ConditionData, tc openshiftTY.TimeoutConfig) error {
+	executeFunc := func() (bool, error) {
+		return isConditionMet(k8sClient, dynamicClient, items)
+	}
+	return funcUtils.ExecuteWithTimeoutAndContinuesSuccessCount(executeFunc, tc.Timeout, tc.ScanInterval, tc.ExpectedSuccessCount)
+}
+
+func isConditionMet(k8sClient client.Client, dynamicClient dynamic.Interface, items []openshiftTY.ConditionData) (bool, error) {
+	notMet := []string{}
+	for _, item := range items {
+		resource, err := Get(k8sClient, dynamicClient, item.APIVersion, item.Kind, item.Name, item.Namespace)
+		if err != nil {
+			if utils.IgnoreNotFoundError(err) != nil {
+				return false, err
+			}
+			notMet = append(notMet, refString(item.ResourceRef))
+			continue
+		}
+
+		met, err := IsConditionMet(resource, item)
+		if err != nil {
+			return false, err
+		}
+		if !met {
+			notMet = append(notMet, refString(item.ResourceRef))
+		}
+	}
+
+	if len(notMet) == 0 {
+		zap.L().Debug("resources conditions met", zap.Any("resources", items))
+		return true, nil
+	}
+	zap.L().Debug("waiting for resources condition", zap.Any("notMet", notMet))
+	return false, nil
+}
+
+// IsConditionMet verifies all the supplied checks on the resource
+func IsConditionMet(resource *unstructured.Unstructured, cd openshiftTY.ConditionData) (bool, error) {
+	if cd.Condition != nil {
+		if !isStatusConditionMet(resource, cd.Condition) {
+			return false, nil
+		}
+	}
+
+	if cd.JSONPath != "" {
+		value, err := getJSONPathValue(resource, cd.JSONPath)
+		if err != nil {
+			return false, err
+		}
+		if value != cd.Value {
+			return false, nil
+		}
+	}
+
+	if cd.Compare != "" {
+		met, err := evaluateCompare(resource, cd.Compare)
+		if err != nil || !met {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func isStatusConditionMet(resource *unstructured.Unstructured, sc *openshiftTY.StatusCondition) bool {
+	conditions, found, err := unstructured.NestedSlice(resource.Object, "status", "conditions")
+	if err != nil || !found {
+		return false
+	}
+	expectedStatus := sc.Status
+	if expectedStatus == "" {
+		expectedStatus = "True"
+	}
+	for _, rawCondition := range conditions {
+		condition, ok := rawCondition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if fmt.Sprintf("%v", condition["type"]) != sc.Type {
+			continue
+		}
+		if !strings.EqualFold(fmt.Sprintf("%v", condition["status"]), expectedStatus) {
+			return false
+		}
+		if sc.Reason != "" && fmt.Sprintf("%v", condition["reason"]) != sc.Reason {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// returns the value of the kubectl style jsonpath, example: "{.status.phase}"
+func getJSONPathValue(resource *unstructured.Unstructured, path string) (string, error) {
+	if !strings.HasPrefix(path, "{") {
+		path = fmt.Sprintf("{%s}", path)
+	}
+	jp := jsonpath.New("condition").AllowMissingKeys(true)
+	err := jp.Parse(path)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	err = jp.Execute(buf, resource.Object)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// evaluates a comparison in the format of "<path> <operator> <value>", see ConditionData.Compare
+// path is a gjson path on the resource, example: "status.readyReplicas >= 3"
+// values are compared as number, if both sides are numbers
+func evaluateCompare(resource *unstructured.Unstructured, compare string) (bool, error) {
+	path, operator, expectedValue, err := parseCompare(compare)
+	if err != nil {
+		return false, err
+	}
+
+	jsonBytes, err := resource.MarshalJSON()
+	if err != nil {
+		return false, err
+	}
+	result := gjson.GetBytes(jsonBytes, path)
+	if !result.Exists() {
+		return false, nil
+	}
+	actualValue := result.String()
+
+	actualNumber, actualErr := strconv.ParseFloat(actualValue, 64)
+	expectedNumber, expectedErr := strconv.ParseFloat(expectedValue, 64)
+	if actualErr == nil && expectedErr == nil {
+		switch operator {
+		case "==":
+			return actualNumber == expectedNumber, nil
+		case "!=":
+			return actualNumber != expectedNumber, nil
+		case ">=":
+			return actualNumber >= expectedNumber, nil
+		case "<=":
+			return actualNumber <= expectedNumber, nil
+		case ">":
+			return actualNumber > expectedNumber, nil
+		case "<":
+			return actualNumber < expectedNumber, nil
+		}
+	}
+
+	switch operator {
+	case "==":
+		return actualValue == expectedValue, nil
+	case "!=":
+		return actualValue != expectedValue, nil
+	}
+	return false, fmt.Errorf("operator '%s' supports only numbers. compare:%s", operator, compare)
+}
+
+// splits the comparison into path, operator and value
+// looks for the operator from the right, outside of the quotes, brackets and parentheses
+// hence the gjson queries can have operators, example: status.conditions.#(type=="Ready").status == "True"
+func parseCompare(compare string) (string, string, string, error) {
+	var quote byte
+	depth := 0
+	for index := len(compare) - 1; index >= 0; index-- {
+		char := compare[index]
+
+		if quote != 0 {
+			if char == quote && (index == 0 || compare[index-1] != '\\') {
+				quote = 0
+			}
+			continue
+		}
+
+		switch char {
+		case '"', '\'':
+			quote = char
+			continue
+		case ')', ']', '}':
+			depth++
+			continue
+		case '(', '[', '{':
+			depth--
+			continue
+		}
+		if depth != 0 {
+			continue
+		}
+
+		operator := ""
+		if index > 0 && utils.ContainsString(compareOperators, compare[index-1:index+1]) {
+			operator = compare[index-1 : index+1]
+		} else if char == '>' || char == '<' {
+			operator = string(char)
+		}
+		if operator == "" {
+			continue
+		}
+
+		path := strings.TrimSpace(compare[:index+1-len(operator)])
+		value := strings.TrimSpace(compare[index+1:])
+		if path == "" || value == "" {
+			return "", "", "", fmt.Errorf("path and value are required. compare:%s", compare)
+		}
+		// removes the quotes around the value
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		return path, operator, value, nil
+	}
+	return "", "", "", fmt.Errorf("operator not found in the compare:%s", compare)
+}
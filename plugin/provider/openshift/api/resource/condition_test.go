@@ -0,0 +1,101 @@
+package api
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseCompare(t *testing.T) {
+	tests := []struct {
+		compare  string
+		path     string
+		operator string
+		value    string
+		isError  bool
+	}{
+		{compare: "status.readyReplicas >= 3", path: "status.readyReplicas", operator: ">=", value: "3"},
+		{compare: "status.readyReplicas>3", path: "status.readyReplicas", operator: ">", value: "3"},
+		{compare: "status.readyReplicas < 3", path: "status.readyReplicas", operator: "<", value: "3"},
+		{compare: "status.readyReplicas <= 3", path: "status.readyReplicas", operator: "<=", value: "3"},
+		{compare: `status.phase == "Running"`, path: "status.phase", operator: "==", value: "Running"},
+		{compare: `status.phase != 'Failed'`, path: "status.phase", operator: "!=", value: "Failed"},
+		{compare: `status.conditions.#(type=="Ready").status == "True"`, path: `status.conditions.#(type=="Ready").status`, operator: "==", value: "True"},
+		{compare: `status.conditions.#(type!="Ready")#.status != "False"`, path: `status.conditions.#(type!="Ready")#.status`, operator: "!=", value: "False"},
+		{compare: `spec.containers.#(resources.limits.cpu>1).name == app`, path: "spec.containers.#(resources.limits.cpu>1).name", operator: "==", value: "app"},
+		{compare: `metadata.annotations.description == "a >= b"`, path: "metadata.annotations.description", operator: "==", value: "a >= b"},
+		{compare: `metadata.annotations.description == "say \"a==b\""`, path: "metadata.annotations.description", operator: "==", value: `say \"a==b\"`},
+		{compare: `metadata.labels.app == `, isError: true},
+		{compare: `== Running`, isError: true},
+		{compare: `status.phase = Running`, isError: true},
+		{compare: `status.conditions.#(type=="Ready").status`, isError: true},
+	}
+
+	for _, test := range tests {
+		path, operator, value, err := parseCompare(test.compare)
+		if test.isError {
+			if err == nil {
+				t.Errorf("compare:%s, expected error, received path:%s, operator:%s, value:%s", test.compare, path, operator, value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("compare:%s, unexpected error: %v", test.compare, err)
+			continue
+		}
+		if path != test.path || operator != test.operator || value != test.value {
+			t.Errorf("compare:%s, expected [%s] [%s] [%s], received [%s] [%s] [%s]", test.compare, test.path, test.operator, test.value, path, operator, value)
+		}
+	}
+}
+
+func TestEvaluateCompare(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "app", "namespace": "default"},
+		"status": map[string]interface{}{
+			"readyReplicas": int64(3),
+			"phase":         "Running",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "False"},
+				map[string]interface{}{"type": "Ready", "status": "True"},
+			},
+		},
+	}}
+
+	tests := []struct {
+		compare  string
+		expected bool
+		isError  bool
+	}{
+		{compare: "status.readyReplicas >= 3", expected: true},
+		{compare: "status.readyReplicas > 3", expected: false},
+		{compare: "status.readyReplicas == 3.0", expected: true},
+		{compare: `status.phase == "Running"`, expected: true},
+		{compare: `status.phase != Running`, expected: false},
+		{compare: `status.conditions.#(type=="Ready").status == "True"`, expected: true},
+		{compare: `status.conditions.#(type=="Available").status == "True"`, expected: false},
+		{compare: `status.conditions.#(type=="Unknown").status == "True"`, expected: false},
+		{compare: "status.unavailable == 0", expected: false},
+		{compare: "status.phase > 3", isError: true},
+		{compare: "status.phase", isError: true},
+	}
+
+	for _, test := range tests {
+		met, err := evaluateCompare(resource, test.compare)
+		if test.isError {
+			if err == nil {
+				t.Errorf("compare:%s, expected error", test.compare)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("compare:%s, unexpected error: %v", test.compare, err)
+			continue
+		}
+		if met != test.expected {
+			t.Errorf("compare:%s, expected:%v, received:%v", test.compare, test.expected, met)
+		}
+	}
+}
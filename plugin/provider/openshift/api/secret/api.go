@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/jkandasa/autoeasy/pkg/json"
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	configMapAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/config_map"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// cluster wide pull secret of OpenShift
+	GlobalPullSecretName      = "pull-secret"
+	GlobalPullSecretNamespace = "openshift-config"
+)
+
+func List(k8sClient client.Client, opts []client.ListOption) (*corev1.SecretList, error) {
+	secretList := &corev1.SecretList{}
+	err := k8sClient.List(context.Background(), secretList, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return secretList, nil
+}
+
+func Get(k8sClient client.Client, name, namespace string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	namespacedName := types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}
+	err := k8sClient.Get(context.Background(), namespacedName, secret)
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func Delete(k8sClient client.Client, secret *corev1.Secret) error {
+	return utils.IgnoreNotFoundError(k8sClient.Delete(context.Background(), secret))
+}
+
+func DeleteOfAll(k8sClient client.Client, secret *corev1.Secret, opts []client.DeleteAllOfOption) error {
+	if secret == nil {
+		secret = &corev1.Secret{}
+	}
+	return k8sClient.DeleteAllOf(context.Background(), secret, opts...)
+}
+
+func Create(k8sClient client.Client, secret *corev1.Secret) error {
+	return k8sClient.Create(context.Background(), secret)
+}
+
+func Update(k8sClient client.Client, secret *corev1.Secret) error {
+	return k8sClient.Update(context.Background(), secret)
+}
+
+func CreateWithMap(k8sClient client.Client, cfg map[string]interface{}) error {
+	secret := &corev1.Secret{}
+	err := formatterUtils.JsonMapToStruct(cfg, secret)
+	if err != nil {
+		return err
+	}
+	return k8sClient.Create(context.Background(), secret)
+}
+
+// New returns a Secret from the config data
+// on docker registry, returns a pull secret of type "kubernetes.io/dockerconfigjson"
+func New(cfg openshiftTY.ConfigData) (*corev1.Secret, error) {
+	data, err := configMapAPI.BuildData(cfg)
+	if err != nil {
+		return nil, err
+	}
+	secretType := corev1.SecretType(cfg.Type)
+	if cfg.DockerRegistry != nil {
+		dockerConfig, err := MergeDockerConfig(nil, *cfg.DockerRegistry)
+		if err != nil {
+			return nil, err
+		}
+		data[corev1.DockerConfigJsonKey] = dockerConfig
+		secretType = corev1.SecretTypeDockerConfigJson
+	}
+	if secretType == "" {
+		secretType = corev1.SecretTypeOpaque
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cfg.Name,
+			Namespace:   cfg.Namespace,
+			Labels:      cfg.Labels,
+			Annotations: cfg.Annotations,
+		},
+		Type: secretType,
+		Data: data,
+	}
+	return secret, nil
+}
+
+// MergeDockerConfig adds or updates the registries credentials on the docker config json
+// other fields of the docker config are preserved
+func MergeDockerConfig(dockerConfig []byte, registries ...openshiftTY.DockerRegistryAuth) ([]byte, error) {
+	config := map[string]interface{}{}
+	if len(dockerConfig) > 0 {
+		err := json.Unmarshal(dockerConfig, &config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid docker config json: %w", err)
+		}
+	}
+	auths, ok := config["auths"].(map[string]interface{})
+	if !ok {
+		auths = map[string]interface{}{}
+	}
+
+	for _, registry := range registries {
+		if registry.Server == "" {
+			return nil, fmt.Errorf("registry server can not be empty. username:%s", registry.Username)
+		}
+		auth := map[string]interface{}{
+			"username": registry.Username,
+			"password": registry.Password,
+			"auth":     base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", registry.Username, registry.Password))),
+		}
+		if registry.Email != "" {
+			auth["email"] = registry.Email
+		}
+		auths[registry.Server] = auth
+	}
+	config["auths"] = auths
+	return json.Marshal(config)
+}
+
+// MergeGlobalPullSecret adds or updates the registries credentials on the cluster global pull secret
+// the cluster rolls out the updated pull secret to all the nodes
+func MergeGlobalPullSecret(k8sClient client.Client, registries ...openshiftTY.DockerRegistryAuth) error {
+	secret, err := Get(k8sClient, GlobalPullSecretName, GlobalPullSecretNamespace)
+	if err != nil {
+		return err
+	}
+	dockerConfig, err := MergeDockerConfig(secret.Data[corev1.DockerConfigJsonKey], registries...)
+	if err != nil {
+		return err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[corev1.DockerConfigJsonKey] = dockerConfig
+	return Update(k8sClient, secret)
+}
@@ -1,29 +1,28 @@
 package k8s
 
 import (
-	"flag"
-	"os"
-	"path/filepath"
-	"sync"
+	"fmt"
 
 	jaegerv1 "github.com/jaegertracing/jaeger-operator/apis/v1"
 	osoperatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
 	routev1 "github.com/openshift/api/route/v1"
+	corsosv1 "github.com/operator-framework/api/pkg/operators/v1"
 	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"go.uber.org/zap"
 
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	restClient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// kubeconfig context and cluster used on the command line helpers
 var (
-	parseConfig        sync.Once
-	kubeConfigFilename *string
+	CLIContext string
+	CLICluster string
 )
 
 type K8SClient struct {
@@ -35,26 +34,24 @@ func New(cfg *openshiftTY.PluginConfig) *K8SClient {
 	return &K8SClient{Config: cfg}
 }
 
-func (k8s *K8SClient) loadConfigFromFile() error {
-	parseConfig.Do(func() { // run only once
-		if os.Getenv("KUBECONFIG") != "" {
-			_kubeConfigFilename := os.Getenv("KUBECONFIG")
-			kubeConfigFilename = &_kubeConfigFilename
-		} else if home := homedir.HomeDir(); home != "" {
-			kubeConfigFilename = flag.String("kubeconfig", filepath.Join(home, ".kube", "config"), "(optional) absolute path to the kubeconfig file")
-		} else {
-			kubeConfigFilename = flag.String("kubeconfig", "", "absolute path to the kubeconfig file")
-		}
-		flag.Parse()
-	})
-
-	config, err := clientcmd.BuildConfigFromFlags("", *kubeConfigFilename)
+// loads the config from the kubeconfig file
+// file location priority: config_file, KUBECONFIG environment, $HOME/.kube/config
+// uses the current context, if the context is not supplied
+// cluster of the context can be overridden with kubeconfig_cluster
+func (k8s *K8SClient) loadConfigFromFile(cfg *openshiftTY.PluginConfig) error {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if cfg.ConfigFile != "" {
+		loadingRules.ExplicitPath = cfg.ConfigFile
+	}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: cfg.Context}
+	overrides.Context.Cluster = cfg.KubeCluster
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
 	if err != nil {
 		return err
 	}
 
 	k8s.restConfig = config
-	return nil
+	return updateProxy(k8s.restConfig, cfg.ProxyURL)
 }
 
 func (k8s *K8SClient) Login(cfg *openshiftTY.PluginConfig, forceRelogin bool) error {
@@ -64,28 +61,64 @@ func (k8s *K8SClient) Login(cfg *openshiftTY.PluginConfig, forceRelogin bool) er
 
 	// load config from file
 	if cfg.LoadFromConfig {
-		err := k8s.loadConfigFromFile()
+		err := k8s.loadConfigFromFile(cfg)
 		if err != nil {
+			// explicitly requested kubeconfig, context or cluster should not be replaced silently
+			if cfg.ConfigFile != "" || cfg.Context != "" || cfg.KubeCluster != "" {
+				return fmt.Errorf("error on loading kubeconfig. config_file:%s, context:%s, cluster:%s, error:%w", cfg.ConfigFile, cfg.Context, cfg.KubeCluster, err)
+			}
 			zap.L().Debug("error on getting kube config, trying with in-cluster configuration", zap.Error(err))
-			// if no config files found or error with config file login, try with in-cluster configurations
-			_restConfig, err := restClient.InClusterConfig()
-			if err != nil {
-				return err
+			// if no default config file found or error with it, try with in-cluster configurations
+			_restConfig, inClusterErr := restClient.InClusterConfig()
+			if inClusterErr != nil {
+				return fmt.Errorf("error on loading kubeconfig and in-cluster configuration. kubeconfig error:%s, in-cluster error:%w", err.Error(), inClusterErr)
 			}
 			k8s.restConfig = _restConfig
 		}
-	} else {
-		// load config from input
-		k8s.restConfig = &restClient.Config{
-			Host:     cfg.Server,
-			Username: cfg.Username,
-			Password: cfg.Password,
+		return nil
+	}
+
+	// load config from input
+	restConfig := &restClient.Config{
+		Host: cfg.Server,
+	}
+
+	// update tls config
+	restConfig.TLSClientConfig.Insecure = cfg.Insecure
+	restConfig.TLSClientConfig.CAFile = cfg.CAFile
+	if cfg.CAData != "" {
+		caData, err := getCAData(cfg.CAData)
+		if err != nil {
+			return err
 		}
+		restConfig.TLSClientConfig.CAData = caData
+	}
 
-		// update insecure
-		k8s.restConfig.TLSClientConfig.Insecure = cfg.Insecure
+	// update proxy
+	err := updateProxy(restConfig, cfg.ProxyURL)
+	if err != nil {
+		return err
+	}
+
+	// update credentials
+	if cfg.Token != "" {
+		restConfig.BearerToken = cfg.Token
+	} else {
+		// exchange username and password with OpenShift OAuth token
+		// falls back to basic auth, if the OAuth server is not available
+		token, err := requestOAuthToken(restConfig, cfg.Username, cfg.Password)
+		if err != nil {
+			return err
+		}
+		if token != "" {
+			restConfig.BearerToken = token
+		} else {
+			restConfig.Username = cfg.Username
+			restConfig.Password = cfg.Password
+		}
 	}
 
+	k8s.restConfig = restConfig
 	return nil
 }
 
@@ -97,8 +130,13 @@ func (k8s *K8SClient) NewClientset() (*kubernetes.Clientset, error) {
 	return kubernetes.NewForConfig(k8s.restConfig)
 }
 
+func (k8s *K8SClient) NewDynamicClient() (dynamic.Interface, error) {
+	return dynamic.NewForConfig(k8s.restConfig)
+}
+
 func NewClientFromRestConfig(restConfig *restClient.Config) (client.Client, error) {
-	client, err := client.New(restConfig, client.Options{})
+	// client with watch support, used on waits
+	client, err := client.NewWithWatch(restConfig, client.Options{})
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +165,11 @@ func registerSchema(client client.Client) error {
 		return err
 	}
 
+	err = corsosv1.AddToScheme(client.Scheme())
+	if err != nil {
+		return err
+	}
+
 	err = osoperatorv1alpha1.AddToScheme(client.Scheme())
 	if err != nil {
 		return err
@@ -152,7 +195,7 @@ func GetKubernetesClient() client.Client {
 
 func GetK8SClientConfig() *K8SClient {
 	zap.L().Debug("loading k8s client")
-	k8sClientConf := &K8SClient{Config: &openshiftTY.PluginConfig{LoadClient: true, LoadFromConfig: true, Insecure: true}}
+	k8sClientConf := &K8SClient{Config: &openshiftTY.PluginConfig{LoadClient: true, LoadFromConfig: true, Insecure: true, Context: CLIContext, KubeCluster: CLICluster}}
 	err := k8sClientConf.Login(k8sClientConf.Config, false)
 	if err != nil {
 		zap.L().Fatal("error on login into k8s cluster", zap.Error(err))
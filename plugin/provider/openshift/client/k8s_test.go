@@ -0,0 +1,69 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com:6443
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: admin
+current-context: dev
+users:
+- name: admin
+  user:
+    token: secret
+`
+
+func TestLoginExplicitConfig(t *testing.T) {
+	// no in-cluster fallback in this test
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "")
+
+	configFile := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(configFile, []byte(testKubeconfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		cfg         openshiftTY.PluginConfig
+		expectError bool
+		host        string
+	}{
+		{name: "valid file", cfg: openshiftTY.PluginConfig{ConfigFile: configFile}, host: "https://dev.example.com:6443"},
+		{name: "missing file", cfg: openshiftTY.PluginConfig{ConfigFile: filepath.Join(t.TempDir(), "missing")}, expectError: true},
+		{name: "missing context", cfg: openshiftTY.PluginConfig{ConfigFile: configFile, Context: "prod"}, expectError: true},
+		{name: "missing cluster", cfg: openshiftTY.PluginConfig{ConfigFile: configFile, KubeCluster: "prod"}, expectError: true},
+	}
+
+	for _, test := range tests {
+		test.cfg.LoadFromConfig = true
+		k8sClient := New(&test.cfg)
+		err := k8sClient.Login(&test.cfg, true)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if k8sClient.GetRestConfig().Host != test.host {
+			t.Errorf("%s: expected host:%s, received:%s", test.name, test.host, k8sClient.GetRestConfig().Host)
+		}
+	}
+}
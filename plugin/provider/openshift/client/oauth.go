@@ -0,0 +1,133 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jkandasa/autoeasy/pkg/json"
+	"go.uber.org/zap"
+	restClient "k8s.io/client-go/rest"
+)
+
+const (
+	oauthMetadataPath   = "/.well-known/oauth-authorization-server"
+	oauthClientID       = "openshift-challenging-client"
+	oauthRequestTimeout = time.Second * 30
+)
+
+// returns the CA data, supports PEM and base64 encoded PEM
+func getCAData(caData string) ([]byte, error) {
+	if strings.Contains(caData, "-----BEGIN") {
+		return []byte(caData), nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(caData))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ca_data, expects PEM or base64 encoded PEM: %w", err)
+	}
+	return decoded, nil
+}
+
+func updateProxy(restConfig *restClient.Config, proxyURL string) error {
+	if proxyURL == "" {
+		return nil
+	}
+	parsedURL, err := url.Parse(proxyURL)
+	if err != nil {
+		return err
+	}
+	restConfig.Proxy = http.ProxyURL(parsedURL)
+	return nil
+}
+
+// requestOAuthToken exchanges the username and password with an OpenShift OAuth access token.
+// returns empty token, if the server does not provide OAuth metadata (not an OpenShift cluster)
+func requestOAuthToken(restConfig *restClient.Config, username, password string) (string, error) {
+	transport, err := restClient.TransportFor(restClient.AnonymousClientConfig(restConfig))
+	if err != nil {
+		return "", err
+	}
+	httpClient := &http.Client{
+		Transport: transport,
+		Timeout:   oauthRequestTimeout,
+		// the token is returned on the redirect location
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	// get authorization endpoint
+	metadataURL := strings.TrimSuffix(restConfig.Host, "/") + oauthMetadataPath
+	res, err := httpClient.Get(metadataURL)
+	if err != nil {
+		return "", err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		zap.L().Debug("OAuth metadata not available, continues with basic auth", zap.String("url", metadataURL), zap.Int("statusCode", res.StatusCode))
+		return "", nil
+	}
+	metadata := struct {
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+	}{}
+	err = json.Unmarshal(body, &metadata)
+	if err != nil {
+		return "", err
+	}
+	if metadata.AuthorizationEndpoint == "" {
+		return "", errors.New("authorization endpoint not found on the OAuth metadata")
+	}
+
+	// request token
+	authorizeURL, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	query := authorizeURL.Query()
+	query.Set("response_type", "token")
+	query.Set("client_id", oauthClientID)
+	authorizeURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, authorizeURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(username, password)
+	req.Header.Set("X-CSRF-Token", "1")
+
+	res, err = httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusFound {
+		return "", fmt.Errorf("error on requesting OAuth token, statusCode:%d, status:%s", res.StatusCode, res.Status)
+	}
+
+	location, err := url.Parse(res.Header.Get("Location"))
+	if err != nil {
+		return "", err
+	}
+	fragment, err := url.ParseQuery(location.Fragment)
+	if err != nil {
+		return "", err
+	}
+	if errMessage := fragment.Get("error"); errMessage != "" {
+		return "", fmt.Errorf("error on requesting OAuth token, error:%s, description:%s", errMessage, fragment.Get("error_description"))
+	}
+	token := fragment.Get("access_token")
+	if token == "" {
+		return "", errors.New("access token not found on the OAuth response")
+	}
+	zap.L().Debug("received OAuth access token", zap.String("username", username))
+	return token, nil
+}
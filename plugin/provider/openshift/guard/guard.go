@@ -0,0 +1,108 @@
+package guard
+
+import (
+	"fmt"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+)
+
+// Object is a resource going to be deleted
+type Object interface {
+	GetName() string
+	GetNamespace() string
+}
+
+// Verify returns the items allowed to delete.
+// protected items are excluded from the list.
+// on preview or without confirmation, the items are printed and not returned.
+// confirmation is required on remove_all, keep_only and remove without data,
+// with patterns or with more matches than the supplied names
+func Verify[T any, PT interface {
+	*T
+	Object
+}](cfg *openshiftTY.ProviderConfig, items []T) ([]T, error) {
+	allowed := make([]T, 0)
+	for index := range items {
+		item := PT(&items[index])
+		if isProtected(cfg, item) {
+			zap.L().Warn("skipped a protected resource from the deletion", zap.String("kind", getKind(cfg, item)), zap.String("name", item.GetName()), zap.String("namespace", item.GetNamespace()))
+			continue
+		}
+		allowed = append(allowed, items[index])
+	}
+
+	if len(allowed) == 0 {
+		return allowed, nil
+	}
+
+	if cfg.Config.Preview || (isConfirmRequired(cfg, len(allowed)) && !cfg.Config.Confirm) {
+		for index := range allowed {
+			item := PT(&allowed[index])
+			zap.L().Info("resource will be deleted", zap.String("kind", getKind(cfg, item)), zap.String("name", item.GetName()), zap.String("namespace", item.GetNamespace()))
+		}
+		if cfg.Config.Preview {
+			zap.L().Info("preview enabled, skipped the deletion", zap.String("kind", cfg.Kind), zap.String("function", cfg.Function), zap.Int("count", len(allowed)))
+			return []T{}, nil
+		}
+		return nil, fmt.Errorf("confirmation required to delete %d resource(s), set 'confirm: true' on the task config or use '--yes' flag. {kind:%s, function:%s}", len(allowed), cfg.Kind, cfg.Function)
+	}
+
+	return allowed, nil
+}
+
+// remove requires confirmation, when the supplied data can select more than the given names
+func isConfirmRequired(cfg *openshiftTY.ProviderConfig, matchCount int) bool {
+	switch cfg.Function {
+	case openshiftTY.FuncRemoveAll, openshiftTY.FuncKeepOnly:
+		return true
+	case openshiftTY.FuncRemove:
+		if len(cfg.Data) == 0 || matchCount > len(cfg.Data) {
+			return true
+		}
+		for _, value := range suppliedValues(cfg.Data) {
+			if utils.IsPattern(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// returns the names and namespaces of the supplied data
+// data can be a name or a map with name and namespace
+func suppliedValues(rawItems []interface{}) []string {
+	values := make([]string, 0)
+	for _, rawItem := range rawItems {
+		switch item := rawItem.(type) {
+		case map[string]interface{}:
+			for _, key := range []string{"name", "namespace"} {
+				if value, found := item[key]; found {
+					values = append(values, fmt.Sprintf("%v", value))
+				}
+			}
+		default:
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+	}
+	return values
+}
+
+// namespaced resources are verified with the namespace and namespace resources with the name
+func isProtected(cfg *openshiftTY.ProviderConfig, item Object) bool {
+	if item.GetNamespace() != "" {
+		return cfg.Config.Protection.IsProtectedNamespace(item.GetNamespace())
+	}
+	if getKind(cfg, item) == openshiftTY.KindNamespace {
+		return cfg.Config.Protection.IsProtectedNamespace(item.GetName())
+	}
+	return false
+}
+
+func getKind(cfg *openshiftTY.ProviderConfig, item Object) string {
+	if kindItem, ok := item.(interface{ GetKind() string }); ok && kindItem.GetKind() != "" {
+		return kindItem.GetKind()
+	}
+	return cfg.Kind
+}
@@ -0,0 +1,70 @@
+package guard
+
+import (
+	"testing"
+
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsConfirmRequired(t *testing.T) {
+	tests := []struct {
+		name       string
+		function   string
+		data       []interface{}
+		matchCount int
+		expected   bool
+	}{
+		{name: "remove all", function: openshiftTY.FuncRemoveAll, matchCount: 1, expected: true},
+		{name: "keep only", function: openshiftTY.FuncKeepOnly, data: []interface{}{"app"}, matchCount: 1, expected: true},
+		{name: "remove without data", function: openshiftTY.FuncRemove, matchCount: 1, expected: true},
+		{name: "remove names", function: openshiftTY.FuncRemove, data: []interface{}{"app", "db"}, matchCount: 2, expected: false},
+		{name: "remove glob", function: openshiftTY.FuncRemove, data: []interface{}{"*"}, matchCount: 1, expected: true},
+		{name: "remove regex", function: openshiftTY.FuncRemove, data: []interface{}{"/.*/"}, matchCount: 1, expected: true},
+		{name: "remove glob on map", function: openshiftTY.FuncRemove, data: []interface{}{map[string]interface{}{"name": "app-*", "namespace": "default"}}, matchCount: 1, expected: true},
+		{name: "remove glob namespace on map", function: openshiftTY.FuncRemove, data: []interface{}{map[string]interface{}{"name": "app", "namespace": "test-?"}}, matchCount: 1, expected: true},
+		{name: "remove name on many namespaces", function: openshiftTY.FuncRemove, data: []interface{}{"app"}, matchCount: 3, expected: true},
+		{name: "add", function: openshiftTY.FuncAdd, matchCount: 1, expected: false},
+	}
+
+	for _, test := range tests {
+		cfg := &openshiftTY.ProviderConfig{Function: test.function, Data: test.data}
+		received := isConfirmRequired(cfg, test.matchCount)
+		if received != test.expected {
+			t.Errorf("%s: expected:%v, received:%v", test.name, test.expected, received)
+		}
+	}
+}
+
+func TestVerifyConfirmation(t *testing.T) {
+	items := []corev1.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "openshift-config"}},
+	}
+
+	// wildcard requires confirmation
+	cfg := &openshiftTY.ProviderConfig{Kind: "ConfigMap", Function: openshiftTY.FuncRemove, Data: []interface{}{"*"}}
+	_, err := Verify(cfg, items)
+	if err == nil {
+		t.Errorf("expected confirmation error")
+	}
+
+	// confirmed, protected namespace excluded
+	cfg.Config.Confirm = true
+	allowed, err := Verify(cfg, items)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(allowed) != 2 {
+		t.Errorf("expected 2 allowed items, received:%d", len(allowed))
+	}
+
+	// preview returns nothing
+	cfg.Config.Preview = true
+	allowed, err = Verify(cfg, items)
+	if err != nil || len(allowed) != 0 {
+		t.Errorf("expected empty list on preview, received:%d, error:%v", len(allowed), err)
+	}
+}
@@ -6,21 +6,31 @@ package openshift
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	templateTY "github.com/jkandasa/autoeasy/pkg/types/template"
 	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
 	clusterAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/cluster"
 	k8s "github.com/jkandasa/autoeasy/plugin/provider/openshift/client"
 	taskCS "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/catalog_source"
+	taskConfigMap "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/config_map"
+	taskCronJob "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/cron_job"
 	taskDeployment "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/deployment"
 	taskICSP "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/image_content_source_policy"
+	taskMirrorSet "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/image_mirror_set"
+	taskJob "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/job"
+	taskMCP "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/machine_config_pool"
 	taskNS "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/namespace"
 	taskPod "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/pod"
+	taskPortForward "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/port_forward"
+	taskResource "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/resource"
 	taskRoute "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/route"
+	taskSecret "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/secret"
 	taskSubscription "github.com/jkandasa/autoeasy/plugin/provider/openshift/task/subscription"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	providerPluginTY "github.com/jkandasa/autoeasy/plugin/provider/types"
 	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,11 +41,20 @@ const (
 )
 
 type Openshift struct {
-	Config        openshiftTY.PluginConfig
-	Client        *k8s.K8SClient
-	K8SClient     client.Client
-	K8SClientSet  *kubernetes.Clientset
-	K8SRestConfig *rest.Config
+	Config       openshiftTY.PluginConfig
+	clusters     map[string]*Cluster
+	portForwards *taskPortForward.Store
+	mutex        sync.Mutex
+}
+
+// Cluster holds the clients of a cluster
+type Cluster struct {
+	Config           *openshiftTY.PluginConfig
+	Client           *k8s.K8SClient
+	K8SClient        client.Client
+	K8SClientSet     *kubernetes.Clientset
+	K8SDynamicClient dynamic.Interface
+	K8SRestConfig    *rest.Config
 }
 
 func New(config map[string]interface{}) (providerPluginTY.Plugin, error) {
@@ -44,7 +63,17 @@ func New(config map[string]interface{}) (providerPluginTY.Plugin, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Openshift{Config: openshiftCfg, Client: k8s.New(&openshiftCfg)}, nil
+	o := &Openshift{Config: openshiftCfg, clusters: map[string]*Cluster{}, portForwards: taskPortForward.NewStore()}
+	o.clusters[openshiftTY.DefaultCluster] = newCluster(&o.Config)
+	for name := range openshiftCfg.Clusters {
+		clusterCfg := openshiftCfg.Clusters[name]
+		o.clusters[name] = newCluster(&clusterCfg)
+	}
+	return o, nil
+}
+
+func newCluster(cfg *openshiftTY.PluginConfig) *Cluster {
+	return &Cluster{Config: cfg, Client: k8s.New(cfg)}
 }
 
 func (o *Openshift) Name() string {
@@ -52,16 +81,47 @@ func (o *Openshift) Name() string {
 }
 
 func (o *Openshift) Start() error {
-	cfg := &o.Config
-	if !cfg.LoadClient {
-		zap.L().Info("loading openshift client is disabled. you have to load it via task")
-		return nil
+	for name, cluster := range o.clusters {
+		if !cluster.Config.LoadClient {
+			zap.L().Info("loading openshift client is disabled. you have to load it via task", zap.String("cluster", name))
+			continue
+		}
+		err := cluster.login(cluster.Config)
+		if err != nil {
+			zap.L().Error("error on login to the cluster", zap.String("cluster", name), zap.Error(err))
+			return err
+		}
 	}
+	return nil
+}
+
+// returns the cluster of the given name
+// named clusters are loaded on the first use, if the login not done on the start
+func (o *Openshift) getCluster(name string) (*Cluster, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 
-	return o.login(cfg)
+	cluster, found := o.clusters[name]
+	if !found {
+		return nil, fmt.Errorf("cluster not defined. cluster:%s", name)
+	}
+	if name != openshiftTY.DefaultCluster && cluster.K8SClient == nil {
+		err := cluster.login(cluster.Config)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cluster, nil
+}
+
+// TaskCompleted called after every task of all the providers
+// port forwards with "close_after" are closed after the given number of tasks
+func (o *Openshift) TaskCompleted(task *templateTY.Task) {
+	o.portForwards.TaskCompleted()
 }
 
 func (o *Openshift) Close() error {
+	o.portForwards.CloseAll()
 	return nil
 }
 
@@ -72,30 +132,82 @@ func (o *Openshift) Execute(task *templateTY.Task) (interface{}, error) {
 		return nil, err
 	}
 
+	// internal functions decides the cluster by itself
+	if config.Kind == openshiftTY.KindInternal {
+		return o.runInternal(config)
+	}
+
+	cluster, err := o.getCluster(config.Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// update protection and confirmation from the plugin config
+	// protection of a named cluster extends the top level protection
+	protection := o.Config.Protection
+	if cluster.Config != &o.Config {
+		protection = protection.Merge(cluster.Config.Protection)
+	}
+	config.Config.Protection = protection.Merge(config.Config.Protection)
+	if o.Config.AutoConfirm {
+		config.Config.Confirm = true
+	}
+
+	// apply, patch and wait_for_condition are supported on all the kinds via dynamic client
+	if config.Function == openshiftTY.FuncApply || config.Function == openshiftTY.FuncPatch || config.Function == openshiftTY.FuncWaitForCondition {
+		return taskResource.Run(cluster.K8SClient, cluster.K8SDynamicClient, config)
+	}
+
+	// exec, logs and copy on deployment are executed on the pods of the deployment
+	if config.Kind == openshiftTY.KindDeployment && isPodFunction(config.Function) {
+		return taskPod.Run(cluster.K8SClient, cluster.K8SClientSet, cluster.K8SRestConfig, config)
+	}
+
 	switch config.Kind {
 	case openshiftTY.KindCatalogSource:
-		return taskCS.Run(o.K8SClient, config)
+		return taskCS.Run(cluster.K8SClient, config)
 
 	case openshiftTY.KindImageContentSourcePolicy:
-		return taskICSP.Run(o.K8SClient, config)
+		return taskICSP.Run(cluster.K8SClient, config)
+
+	case openshiftTY.KindImageDigestMirrorSet, openshiftTY.KindImageTagMirrorSet:
+		return taskMirrorSet.Run(cluster.K8SClient, config)
+
+	case openshiftTY.KindMachineConfigPool:
+		return taskMCP.Run(cluster.K8SClient, config)
 
 	case openshiftTY.KindNamespace:
-		return taskNS.Run(o.K8SClient, config)
+		return taskNS.Run(cluster.K8SClient, config)
 
 	case openshiftTY.KindSubscription:
-		return taskSubscription.Run(o.K8SClient, config)
+		return taskSubscription.Run(cluster.K8SClient, config)
 
 	case openshiftTY.KindDeployment:
-		return taskDeployment.Run(o.K8SClient, config)
+		return taskDeployment.Run(cluster.K8SClient, config)
 
 	case openshiftTY.KindRoute:
-		return taskRoute.Run(o.K8SClient, config)
+		return taskRoute.Run(cluster.K8SClient, config)
 
 	case openshiftTY.KindPod:
-		return taskPod.Run(o.K8SClient, config)
+		return taskPod.Run(cluster.K8SClient, cluster.K8SClientSet, cluster.K8SRestConfig, config)
 
-	case openshiftTY.KindInternal:
-		return o.runInternal(config)
+	case openshiftTY.KindResource:
+		return taskResource.Run(cluster.K8SClient, cluster.K8SDynamicClient, config)
+
+	case openshiftTY.KindJob:
+		return taskJob.Run(cluster.K8SClient, cluster.K8SClientSet, config)
+
+	case openshiftTY.KindCronJob:
+		return taskCronJob.Run(cluster.K8SClient, config)
+
+	case openshiftTY.KindSecret:
+		return taskSecret.Run(cluster.K8SClient, config)
+
+	case openshiftTY.KindConfigMap:
+		return taskConfigMap.Run(cluster.K8SClient, config)
+
+	case openshiftTY.KindPortForward:
+		return o.portForwards.Run(cluster.K8SRestConfig, config)
 
 	default:
 		return nil, fmt.Errorf("invalid kind:[%s]", config.Kind)
@@ -103,6 +215,14 @@ func (o *Openshift) Execute(task *templateTY.Task) (interface{}, error) {
 	}
 }
 
+func isPodFunction(function string) bool {
+	switch function {
+	case openshiftTY.FuncExec, openshiftTY.FuncLogs, openshiftTY.FuncCopyToPod, openshiftTY.FuncCopyFromPod:
+		return true
+	}
+	return false
+}
+
 func (o *Openshift) runInternal(cfg *openshiftTY.ProviderConfig) (interface{}, error) {
 	switch cfg.Function {
 	case openshiftTY.FuncLogin:
@@ -114,10 +234,24 @@ func (o *Openshift) runInternal(cfg *openshiftTY.ProviderConfig) (interface{}, e
 		if err != nil {
 			return nil, err
 		}
-		return nil, o.login(osCfg)
+		// login to a new cluster or updates the existing cluster
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+		cluster, found := o.clusters[cfg.Cluster]
+		if !found {
+			cluster = newCluster(osCfg)
+			o.clusters[cfg.Cluster] = cluster
+		}
+		return nil, cluster.login(osCfg)
 
 	case openshiftTY.FuncLogout:
-		return nil, o.logout()
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+		cluster, found := o.clusters[cfg.Cluster]
+		if !found {
+			return nil, fmt.Errorf("cluster not defined. cluster:%s", cfg.Cluster)
+		}
+		return nil, cluster.logout()
 
 	case openshiftTY.FuncPrintInfo:
 		return nil, errors.New("not implemented yet")
@@ -126,7 +260,7 @@ func (o *Openshift) runInternal(cfg *openshiftTY.ProviderConfig) (interface{}, e
 	return nil, fmt.Errorf("unknown function. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
 }
 
-func (o *Openshift) login(cfg *openshiftTY.PluginConfig) error {
+func (c *Cluster) login(cfg *openshiftTY.PluginConfig) error {
 	if !cfg.LoadFromConfig {
 		err := cfg.Validate()
 		if err != nil {
@@ -135,38 +269,48 @@ func (o *Openshift) login(cfg *openshiftTY.PluginConfig) error {
 	}
 
 	// login
-	err := o.Client.Login(cfg, true)
+	err := c.Client.Login(cfg, true)
 	if err != nil {
 		zap.L().Error("error on doing login", zap.Error(err))
 		return err
 	}
 
 	// load client
-	kubeClient, err := o.Client.NewClient()
+	kubeClient, err := c.Client.NewClient()
 	if err != nil {
 		zap.L().Error("error on loading kubernetes client", zap.Error(err))
 		return err
 	}
-	o.K8SClient = kubeClient
+	c.K8SClient = kubeClient
 
 	// load client set
-	kubeClientSet, err := o.Client.NewClientset()
+	kubeClientSet, err := c.Client.NewClientset()
 	if err != nil {
 		zap.L().Error("error on loading kubernetes client set", zap.Error(err))
 		return err
 	}
-	o.K8SClientSet = kubeClientSet
+	c.K8SClientSet = kubeClientSet
+
+	// load dynamic client
+	kubeDynamicClient, err := c.Client.NewDynamicClient()
+	if err != nil {
+		zap.L().Error("error on loading kubernetes dynamic client", zap.Error(err))
+		return err
+	}
+	c.K8SDynamicClient = kubeDynamicClient
 
 	// load rest config
-	o.K8SRestConfig = o.Client.GetRestConfig()
+	c.K8SRestConfig = c.Client.GetRestConfig()
 
 	zap.L().Info("kubernetes client loaded successfully")
-	clusterAPI.PrintClusterInfo(o.K8SClient, o.K8SClientSet)
+	clusterAPI.PrintClusterInfo(c.K8SClient, c.K8SClientSet)
 	return nil
 }
 
-func (o *Openshift) logout() error {
-	o.K8SClient = nil
-	o.K8SClientSet = nil
+func (c *Cluster) logout() error {
+	c.K8SClient = nil
+	c.K8SClientSet = nil
+	c.K8SDynamicClient = nil
+	c.K8SRestConfig = nil
 	return nil
 }
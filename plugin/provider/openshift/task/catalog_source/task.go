@@ -5,11 +5,13 @@ import (
 	"strings"
 
 	"github.com/jkandasa/autoeasy/pkg/utils"
-	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
 	csAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/catalog_source"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/watch"
 	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -28,30 +30,39 @@ func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{},
 }
 
 func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
-	opts := []client.ListOption{
-		client.InNamespace(""),
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
 	}
 	csList, err := csAPI.List(k8sClient, opts)
 	if err != nil {
 		zap.L().Fatal("error on getting CatalogSource list", zap.Error(err))
 	}
 
+	// filter by namespace
+	selectedItems := make([]corsosv1alpha1.CatalogSource, 0)
+	for _, cs := range csList.Items {
+		if cfg.Config.Selector.IsNamespaceSelected(cs.Namespace) {
+			selectedItems = append(selectedItems, cs)
+		}
+	}
+
 	if cfg.Function == openshiftTY.FuncRemoveAll {
-		return delete(k8sClient, cfg, csList.Items)
-	} else if cfg.Function == openshiftTY.FuncRemoveAll || cfg.Function == openshiftTY.FuncKeepOnly {
+		return delete(k8sClient, cfg, selectedItems)
+	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
 		deletionList := make([]corsosv1alpha1.CatalogSource, 0)
 
 		suppliedItems := utils.ToStringSlice(cfg.Data)
 
 		isRemove := cfg.Function == openshiftTY.FuncRemove
 
-		for _, cs := range csList.Items {
+		for _, cs := range selectedItems {
 			if isRemove { // remove
-				if utils.ContainsString(suppliedItems, cs.Name) {
+				if len(suppliedItems) == 0 || utils.MatchString(suppliedItems, cs.Name) {
 					deletionList = append(deletionList, cs)
 				}
 			} else { // keep only
-				if !utils.ContainsString(suppliedItems, cs.Name) {
+				if !utils.MatchString(suppliedItems, cs.Name) {
 					deletionList = append(deletionList, cs)
 				}
 			}
@@ -64,8 +75,9 @@ func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) err
 }
 
 func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []corsosv1alpha1.CatalogSource) error {
-	if len(items) == 0 {
-		return nil
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
 	}
 	for _, cs := range items {
 		err := csAPI.Delete(k8sClient, &cs)
@@ -123,7 +135,7 @@ func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
 				zap.L().Fatal("error on creating CatalogSource", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace), zap.Error(err))
 			}
 			zap.L().Info("CatalogSource created", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace))
-			err = waitForCatalogSource(k8sClient, cfg, metadata.Name)
+			err = waitForCatalogSource(k8sClient, cfg, metadata.Name, metadata.Namespace)
 			if err != nil {
 				return err
 			}
@@ -133,32 +145,17 @@ func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
 	return nil
 }
 
-func waitForCatalogSource(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, name string) error {
-	executeFunc := func() (bool, error) {
-		return isReady(k8sClient, name)
-	}
-	tc := cfg.Config.TimeoutConfig
-	return funcUtils.ExecuteWithTimeoutAndContinuesSuccessCount(executeFunc, tc.Timeout, tc.ScanInterval, tc.ExpectedSuccessCount)
-}
-
-func isReady(k8sClient client.Client, name string) (bool, error) {
-	opts := []client.ListOption{
-		client.InNamespace(""),
-	}
-	csList, err := csAPI.List(k8sClient, opts)
-	if err == nil {
-		for _, cs := range csList.Items {
-			if cs.Name == name {
-				if cs.Status.GRPCConnectionState != nil && strings.ToLower(cs.Status.GRPCConnectionState.LastObservedState) == "ready" {
-					zap.L().Debug("CatalogSource is ready", zap.Any("name", name), zap.Any("namespace", cs.Namespace))
-					return true, nil
-				}
-			}
+func waitForCatalogSource(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, name, namespace string) error {
+	target := types.NamespacedName{Name: name, Namespace: namespace}
+	newList := func() client.ObjectList { return &corsosv1alpha1.CatalogSourceList{} }
+	isReady := func(objects map[types.NamespacedName]client.Object) (bool, error) {
+		cs, ok := objects[target].(*corsosv1alpha1.CatalogSource)
+		if ok && cs.Status.GRPCConnectionState != nil && strings.ToLower(cs.Status.GRPCConnectionState.LastObservedState) == "ready" {
+			zap.L().Debug("CatalogSource is ready", zap.Any("name", name), zap.Any("namespace", cs.Namespace))
+			return true, nil
 		}
-	} else {
-		return false, err
+		zap.L().Debug("waiting for CatalogSource is getting ready", zap.Any("name", name))
+		return false, nil
 	}
-
-	zap.L().Debug("waiting for CatalogSource is getting ready", zap.Any("name", name))
-	return false, nil
+	return watch.WaitFor(k8sClient, newList, []types.NamespacedName{target}, isReady, cfg.Config.TimeoutConfig)
 }
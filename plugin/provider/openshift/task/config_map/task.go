@@ -0,0 +1,171 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	configMapAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/config_map"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	switch cfg.Function {
+	case openshiftTY.FuncAdd:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return nil, add(k8sClient, cfg)
+
+	case openshiftTY.FuncKeepOnly, openshiftTY.FuncRemove:
+		if len(cfg.Data) == 0 && !cfg.Config.Selector.IsDefined() {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		fallthrough
+	case openshiftTY.FuncRemoveAll:
+		return nil, performDelete(k8sClient, cfg)
+
+	case openshiftTY.FuncGet:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return get(k8sClient, cfg)
+
+	}
+
+	return nil, fmt.Errorf("unknown function. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+}
+
+func get(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	configMaps := make([]corev1.ConfigMap, 0)
+	for _, item := range utils.ToNamespacedNameSlice(cfg.Data) {
+		configMap, err := configMapAPI.Get(k8sClient, item.Name, item.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		configMaps = append(configMaps, *configMap)
+	}
+	if len(configMaps) == 1 {
+		return configMaps[0], nil
+	}
+	return configMaps, nil
+}
+
+func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
+	}
+	configMapList, err := configMapAPI.List(k8sClient, opts)
+	if err != nil {
+		zap.L().Fatal("error on getting ConfigMap list", zap.Error(err))
+	}
+
+	// filter by namespace
+	selectedItems := make([]corev1.ConfigMap, 0)
+	for _, configMap := range configMapList.Items {
+		if cfg.Config.Selector.IsNamespaceSelected(configMap.Namespace) {
+			selectedItems = append(selectedItems, configMap)
+		}
+	}
+
+	if cfg.Function == openshiftTY.FuncRemoveAll {
+		return delete(k8sClient, cfg, selectedItems)
+	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
+		deletionList := make([]corev1.ConfigMap, 0)
+
+		suppliedItems := utils.ToNamespacedNamePatterns(cfg.Data)
+
+		isRemove := cfg.Function == openshiftTY.FuncRemove
+
+		for _, configMap := range selectedItems {
+			if isRemove { // remove
+				if len(suppliedItems) == 0 || utils.MatchNamespacedName(suppliedItems, configMap.ObjectMeta) {
+					deletionList = append(deletionList, configMap)
+				}
+			} else { // keep only
+				if !utils.MatchNamespacedName(suppliedItems, configMap.ObjectMeta) {
+					deletionList = append(deletionList, configMap)
+				}
+			}
+		}
+
+		return delete(k8sClient, cfg, deletionList)
+	}
+	return nil
+}
+
+func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []corev1.ConfigMap) error {
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	for _, configMap := range items {
+		err := configMapAPI.Delete(k8sClient, &configMap)
+		if err != nil {
+			return err
+		}
+		zap.L().Debug("deleted a ConfigMap", zap.String("name", configMap.Name), zap.String("namespace", configMap.Namespace))
+	}
+	return nil
+}
+
+// data can be a ConfigMap manifest or a config data with literals, files, directories and templates
+func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	for _, cfgRaw := range cfg.Data {
+		configMapCfg, ok := cfgRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var configMap *corev1.ConfigMap
+		if _, isManifest := configMapCfg["metadata"]; isManifest {
+			configMap = &corev1.ConfigMap{}
+			err := formatterUtils.JsonMapToStruct(configMapCfg, configMap)
+			if err != nil {
+				return err
+			}
+		} else {
+			configData := openshiftTY.ConfigData{}
+			err := formatterUtils.YamlInterfaceToStruct(configMapCfg, &configData)
+			if err != nil {
+				return err
+			}
+			configMap, err = configMapAPI.New(configData)
+			if err != nil {
+				zap.L().Error("error on building ConfigMap data", zap.String("name", configData.Name), zap.String("namespace", configData.Namespace), zap.Error(err))
+				return err
+			}
+		}
+
+		existing, err := configMapAPI.Get(k8sClient, configMap.Name, configMap.Namespace)
+		if utils.IgnoreNotFoundError(err) != nil {
+			return err
+		}
+		found := err == nil
+		if found {
+			zap.L().Debug("ConfigMap exists", zap.String("name", configMap.Name), zap.String("namespace", configMap.Namespace))
+			if cfg.Config.Recreate {
+				zap.L().Debug("ConfigMap recreate enabled", zap.String("name", configMap.Name), zap.String("namespace", configMap.Namespace))
+				err = configMapAPI.Delete(k8sClient, existing)
+				if err != nil {
+					return err
+				}
+				found = false
+			}
+		}
+		if !found {
+			err = configMapAPI.Create(k8sClient, configMap)
+			if err != nil {
+				zap.L().Error("error on creating ConfigMap", zap.String("name", configMap.Name), zap.String("namespace", configMap.Namespace), zap.Error(err))
+				return err
+			}
+			zap.L().Info("ConfigMap created", zap.String("name", configMap.Name), zap.String("namespace", configMap.Namespace))
+		}
+	}
+	return nil
+}
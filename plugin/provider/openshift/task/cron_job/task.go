@@ -0,0 +1,178 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	cronJobAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/cron_job"
+	jobAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/job"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	cfg.Config.TimeoutConfig.UpdateJobDefaults()
+
+	switch cfg.Function {
+	case openshiftTY.FuncAdd:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return nil, add(k8sClient, cfg)
+
+	case openshiftTY.FuncKeepOnly, openshiftTY.FuncRemove:
+		if len(cfg.Data) == 0 && !cfg.Config.Selector.IsDefined() {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		fallthrough
+	case openshiftTY.FuncRemoveAll:
+		return nil, performDelete(k8sClient, cfg)
+
+	case openshiftTY.FuncTrigger:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return trigger(k8sClient, cfg)
+
+	}
+
+	return nil, fmt.Errorf("unknown function. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+}
+
+// creates jobs from the CronJobs, returns the status of the jobs
+func trigger(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	results := make([]openshiftTY.JobStatus, 0)
+	for _, rawItem := range cfg.Data {
+		request := openshiftTY.TriggerRequest{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &request)
+		if err != nil {
+			return nil, err
+		}
+
+		job, err := cronJobAPI.Trigger(k8sClient, request.Name, request.Namespace, request.JobName)
+		if err != nil {
+			zap.L().Error("error on triggering a CronJob", zap.String("name", request.Name), zap.String("namespace", request.Namespace), zap.Error(err))
+			return nil, err
+		}
+		zap.L().Info("CronJob triggered", zap.String("name", request.Name), zap.String("namespace", request.Namespace), zap.String("job", job.Name))
+
+		if request.Wait {
+			err = jobAPI.WaitForCompletion(k8sClient, []string{job.Name}, job.Namespace, cfg.Config.TimeoutConfig)
+			if err != nil {
+				return nil, err
+			}
+			job, err = jobAPI.Get(k8sClient, job.Name, job.Namespace)
+			if err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, jobAPI.GetStatus(job))
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
+	}
+	cronJobList, err := cronJobAPI.List(k8sClient, opts)
+	if err != nil {
+		zap.L().Fatal("error on getting CronJob list", zap.Error(err))
+	}
+
+	// filter by namespace
+	selectedItems := make([]batchv1.CronJob, 0)
+	for _, cronJob := range cronJobList.Items {
+		if cfg.Config.Selector.IsNamespaceSelected(cronJob.Namespace) {
+			selectedItems = append(selectedItems, cronJob)
+		}
+	}
+
+	if cfg.Function == openshiftTY.FuncRemoveAll {
+		return delete(k8sClient, cfg, selectedItems)
+	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
+		deletionList := make([]batchv1.CronJob, 0)
+
+		suppliedItems := utils.ToNamespacedNamePatterns(cfg.Data)
+
+		isRemove := cfg.Function == openshiftTY.FuncRemove
+
+		for _, cronJob := range selectedItems {
+			if isRemove { // remove
+				if len(suppliedItems) == 0 || utils.MatchNamespacedName(suppliedItems, cronJob.ObjectMeta) {
+					deletionList = append(deletionList, cronJob)
+				}
+			} else { // keep only
+				if !utils.MatchNamespacedName(suppliedItems, cronJob.ObjectMeta) {
+					deletionList = append(deletionList, cronJob)
+				}
+			}
+		}
+
+		return delete(k8sClient, cfg, deletionList)
+	}
+	return nil
+}
+
+func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []batchv1.CronJob) error {
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	for _, cronJob := range items {
+		err := cronJobAPI.Delete(k8sClient, &cronJob)
+		if err != nil {
+			return err
+		}
+		zap.L().Debug("deleted a CronJob", zap.String("name", cronJob.Name), zap.String("namespace", cronJob.Namespace))
+	}
+	return nil
+}
+
+func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	for _, cfgRaw := range cfg.Data {
+		cronJobCfg, ok := cfgRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		metadata, err := utils.GetObjectMeta(cronJobCfg)
+		if err != nil {
+			zap.L().Fatal("error on getting object meta", zap.Any("metadata", metadata), zap.Error(err))
+		}
+
+		cronJob, err := cronJobAPI.Get(k8sClient, metadata.Name, metadata.Namespace)
+		if utils.IgnoreNotFoundError(err) != nil {
+			return err
+		}
+		found := err == nil
+		if found {
+			zap.L().Debug("CronJob exists", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace))
+			if cfg.Config.Recreate {
+				zap.L().Debug("CronJob recreate enabled", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace))
+				err = cronJobAPI.Delete(k8sClient, cronJob)
+				if err != nil {
+					return err
+				}
+				found = false
+			}
+		}
+		if !found {
+			err = cronJobAPI.CreateWithMap(k8sClient, cronJobCfg)
+			if err != nil {
+				zap.L().Fatal("error on creating CronJob", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace), zap.Error(err))
+			}
+			zap.L().Info("CronJob created", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace))
+		}
+	}
+	return nil
+}
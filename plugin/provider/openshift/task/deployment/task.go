@@ -5,6 +5,7 @@ import (
 
 	"github.com/jkandasa/autoeasy/pkg/utils"
 	deploymentAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/deployment"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
@@ -20,7 +21,7 @@ func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{},
 		return nil, add(k8sClient, cfg)
 
 	case openshiftTY.FuncKeepOnly, openshiftTY.FuncRemove:
-		if len(cfg.Data) == 0 {
+		if len(cfg.Data) == 0 && !cfg.Config.Selector.IsDefined() {
 			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
 		}
 		fallthrough
@@ -64,30 +65,39 @@ func waitForReady(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) erro
 }
 
 func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
-	opts := []client.ListOption{
-		client.InNamespace(""),
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
 	}
 	deploymentList, err := deploymentAPI.List(k8sClient, opts)
 	if err != nil {
 		zap.L().Fatal("error on getting Deployment list", zap.Error(err))
 	}
 
+	// filter by namespace
+	selectedItems := make([]appsv1.Deployment, 0)
+	for _, deployment := range deploymentList.Items {
+		if cfg.Config.Selector.IsNamespaceSelected(deployment.Namespace) {
+			selectedItems = append(selectedItems, deployment)
+		}
+	}
+
 	if cfg.Function == openshiftTY.FuncRemoveAll {
-		return delete(k8sClient, cfg, deploymentList.Items)
+		return delete(k8sClient, cfg, selectedItems)
 	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
 		deletionList := make([]appsv1.Deployment, 0)
 
-		suppliedItems := utils.ToNamespacedNameSlice(cfg.Data)
+		suppliedItems := utils.ToNamespacedNamePatterns(cfg.Data)
 
 		isRemove := cfg.Function == openshiftTY.FuncRemove
 
-		for _, deployment := range deploymentList.Items {
+		for _, deployment := range selectedItems {
 			if isRemove { // remove
-				if utils.ContainsNamespacedName(suppliedItems, deployment.ObjectMeta) {
+				if len(suppliedItems) == 0 || utils.MatchNamespacedName(suppliedItems, deployment.ObjectMeta) {
 					deletionList = append(deletionList, deployment)
 				}
 			} else { // keep only
-				if utils.ContainsNamespacedName(suppliedItems, deployment.ObjectMeta) {
+				if !utils.MatchNamespacedName(suppliedItems, deployment.ObjectMeta) {
 					deletionList = append(deletionList, deployment)
 				}
 			}
@@ -100,8 +110,9 @@ func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) err
 }
 
 func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []appsv1.Deployment) error {
-	if len(items) == 0 {
-		return nil
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
 	}
 	for _, deployment := range items {
 		err := deploymentAPI.Delete(k8sClient, &deployment)
@@ -3,7 +3,9 @@ package task
 import (
 	"github.com/jkandasa/autoeasy/pkg/utils"
 	icspAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/image_content_source_policy"
-	nodeAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/node"
+	mirrorSetAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/image_mirror_set"
+	mcpAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/machine_config_pool"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	"github.com/openshift/api/operator/v1alpha1"
 	"go.uber.org/zap"
@@ -18,14 +20,18 @@ func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{},
 	case openshiftTY.FuncKeepOnly, openshiftTY.FuncRemove, openshiftTY.FuncRemoveAll:
 		return nil, performDelete(k8sClient, cfg)
 
+	case openshiftTY.FuncConvertToIDMS:
+		return nil, convertToIDMS(k8sClient, cfg)
+
 	}
 
 	return nil, nil
 }
 
 func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
-	opts := []client.ListOption{
-		client.InNamespace(""),
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
 	}
 	icspList, err := icspAPI.List(k8sClient, opts)
 	if err != nil {
@@ -34,7 +40,7 @@ func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) err
 
 	if cfg.Function == openshiftTY.FuncRemoveAll {
 		return delete(k8sClient, cfg, icspList.Items)
-	} else if cfg.Function == openshiftTY.FuncRemoveAll || cfg.Function == openshiftTY.FuncKeepOnly {
+	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
 		deletionList := make([]v1alpha1.ImageContentSourcePolicy, 0)
 
 		suppliedItems := utils.ToStringSlice(cfg.Data)
@@ -43,11 +49,11 @@ func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) err
 
 		for _, icsp := range icspList.Items {
 			if isRemove { // remove
-				if utils.ContainsString(suppliedItems, icsp.Name) {
+				if len(suppliedItems) == 0 || utils.MatchString(suppliedItems, icsp.Name) {
 					deletionList = append(deletionList, icsp)
 				}
 			} else { // keep only
-				if !utils.ContainsString(suppliedItems, icsp.Name) {
+				if !utils.MatchString(suppliedItems, icsp.Name) {
 					deletionList = append(deletionList, icsp)
 				}
 			}
@@ -60,8 +66,13 @@ func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) err
 }
 
 func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []v1alpha1.ImageContentSourcePolicy) error {
-	if len(items) == 0 {
-		return nil
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	snapshot, err := mcpAPI.Snapshot(k8sClient, nil)
+	if err != nil {
+		return err
 	}
 	for _, icsp := range items {
 		err := icspAPI.Delete(k8sClient, &icsp)
@@ -70,7 +81,7 @@ func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []v1
 		}
 		zap.L().Debug("deleted a ImageContentSourcePolicy", zap.String("name", icsp.Name))
 	}
-	return nodeAPI.WaitForNodesReady(k8sClient, cfg.Config.TimeoutConfig)
+	return mcpAPI.WaitForRollout(k8sClient, nil, snapshot, cfg.Config.TimeoutConfig)
 }
 
 func add(k8sClient client.Client, task *openshiftTY.ProviderConfig) error {
@@ -79,6 +90,11 @@ func add(k8sClient client.Client, task *openshiftTY.ProviderConfig) error {
 		return nil
 	}
 
+	snapshot, err := mcpAPI.Snapshot(k8sClient, nil)
+	if err != nil {
+		return err
+	}
+	changed := false
 	for _, cfgRaw := range task.Data {
 		icspCfg, ok := cfgRaw.(map[string]interface{})
 		if !ok {
@@ -98,12 +114,19 @@ func add(k8sClient client.Client, task *openshiftTY.ProviderConfig) error {
 			zap.L().Fatal("error on getting object meta", zap.Any("metadata", metadata), zap.Error(err))
 		}
 		found := false
+		// recreate with the same spec does not change the rendered configuration of the pools
+		specChanged := true
 		for _, icsp := range icspList.Items {
 			if icsp.ObjectMeta.Name == metadata.Name {
 				zap.L().Debug("imageContentSourcePolicy exists", zap.String("name", metadata.Name))
 				found = true
 				if task.Config.Recreate {
 					zap.L().Debug("imageContentSourcePolicy recreate enabled", zap.String("name", metadata.Name))
+					isEqual, err := icspAPI.IsSpecEqual(&icsp, icspCfg)
+					if err != nil {
+						return err
+					}
+					specChanged = !isEqual
 					err = icspAPI.Delete(k8sClient, &icsp)
 					if err != nil {
 						return err
@@ -119,9 +142,69 @@ func add(k8sClient client.Client, task *openshiftTY.ProviderConfig) error {
 				zap.L().Fatal("error on creating imageContentSourcePolicy", zap.String("name", metadata.Name), zap.Error(err))
 			}
 			zap.L().Info("imageContentSourcePolicy created", zap.String("name", metadata.Name))
+			if specChanged {
+				changed = true
+			}
+		}
+	}
+
+	// without changes, waits only for the current rollout
+	if !changed {
+		snapshot = nil
+	}
+	return mcpAPI.WaitForRollout(k8sClient, nil, snapshot, task.Config.TimeoutConfig)
+}
+
+// creates ImageDigestMirrorSet from the existing ImageContentSourcePolicy, converts all, if no names supplied
+// the ImageContentSourcePolicy is not removed
+func convertToIDMS(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	icspList, err := icspAPI.List(k8sClient, []client.ListOption{})
+	if err != nil {
+		return err
+	}
+	idmsList, err := mirrorSetAPI.List(k8sClient, openshiftTY.KindImageDigestMirrorSet, []client.ListOption{})
+	if err != nil {
+		return err
+	}
 
+	suppliedItems := utils.ToStringSlice(cfg.Data)
+
+	for _, icsp := range icspList.Items {
+		if len(suppliedItems) > 0 && !utils.MatchString(suppliedItems, icsp.Name) {
+			continue
+		}
+		found := false
+		for _, idms := range idmsList.Items {
+			if idms.GetName() == icsp.Name {
+				zap.L().Debug("imageDigestMirrorSet exists", zap.String("name", icsp.Name))
+				found = true
+				if cfg.Config.Recreate {
+					zap.L().Debug("imageDigestMirrorSet recreate enabled", zap.String("name", icsp.Name))
+					err = mirrorSetAPI.Delete(k8sClient, &idms)
+					if err != nil {
+						return err
+					}
+					found = false
+				}
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		idms, err := mirrorSetAPI.FromICSP(&icsp)
+		if err != nil {
+			return err
+		}
+		err = mirrorSetAPI.Create(k8sClient, idms)
+		if err != nil {
+			zap.L().Error("error on creating imageDigestMirrorSet", zap.String("name", icsp.Name), zap.Error(err))
+			return err
 		}
+		zap.L().Info("imageContentSourcePolicy converted to imageDigestMirrorSet", zap.String("name", icsp.Name))
 	}
 
-	return nodeAPI.WaitForNodesReady(k8sClient, task.Config.TimeoutConfig)
+	// the mirror sets hold the same mirrors as the ImageContentSourcePolicy,
+	// the rendered configuration may not change, waits only for the current rollout
+	return mcpAPI.WaitForRollout(k8sClient, nil, nil, cfg.Config.TimeoutConfig)
 }
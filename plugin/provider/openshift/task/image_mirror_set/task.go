@@ -0,0 +1,150 @@
+package task
+
+import (
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	mirrorSetAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/image_mirror_set"
+	mcpAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/machine_config_pool"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Run handles ImageDigestMirrorSet and ImageTagMirrorSet kinds
+func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	switch cfg.Function {
+	case openshiftTY.FuncAdd:
+		return nil, add(k8sClient, cfg)
+
+	case openshiftTY.FuncKeepOnly, openshiftTY.FuncRemove, openshiftTY.FuncRemoveAll:
+		return nil, performDelete(k8sClient, cfg)
+
+	}
+
+	return nil, nil
+}
+
+func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
+	}
+	mirrorSetList, err := mirrorSetAPI.List(k8sClient, cfg.Kind, opts)
+	if err != nil {
+		zap.L().Error("error on getting image mirror set list", zap.String("kind", cfg.Kind), zap.Error(err))
+		return err
+	}
+
+	if cfg.Function == openshiftTY.FuncRemoveAll {
+		return delete(k8sClient, cfg, mirrorSetList.Items)
+	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
+		deletionList := make([]unstructured.Unstructured, 0)
+
+		suppliedItems := utils.ToStringSlice(cfg.Data)
+
+		isRemove := cfg.Function == openshiftTY.FuncRemove
+
+		for _, mirrorSet := range mirrorSetList.Items {
+			if isRemove { // remove
+				if len(suppliedItems) == 0 || utils.MatchString(suppliedItems, mirrorSet.GetName()) {
+					deletionList = append(deletionList, mirrorSet)
+				}
+			} else { // keep only
+				if !utils.MatchString(suppliedItems, mirrorSet.GetName()) {
+					deletionList = append(deletionList, mirrorSet)
+				}
+			}
+		}
+
+		return delete(k8sClient, cfg, deletionList)
+	}
+	return nil
+
+}
+
+func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []unstructured.Unstructured) error {
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	snapshot, err := mcpAPI.Snapshot(k8sClient, nil)
+	if err != nil {
+		return err
+	}
+	for _, mirrorSet := range items {
+		err := mirrorSetAPI.Delete(k8sClient, &mirrorSet)
+		if err != nil {
+			return err
+		}
+		zap.L().Debug("deleted an image mirror set", zap.String("kind", cfg.Kind), zap.String("name", mirrorSet.GetName()))
+	}
+	return mcpAPI.WaitForRollout(k8sClient, nil, snapshot, cfg.Config.TimeoutConfig)
+}
+
+func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	if len(cfg.Data) == 0 {
+		// TODO: report error
+		return nil
+	}
+
+	mirrorSetList, err := mirrorSetAPI.List(k8sClient, cfg.Kind, []client.ListOption{})
+	if err != nil {
+		zap.L().Error("error on getting image mirror set list", zap.String("kind", cfg.Kind), zap.Error(err))
+		return err
+	}
+
+	snapshot, err := mcpAPI.Snapshot(k8sClient, nil)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, cfgRaw := range cfg.Data {
+		mirrorSetCfg, ok := cfgRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		metadata, err := utils.GetObjectMeta(mirrorSetCfg)
+		if err != nil {
+			zap.L().Error("error on getting object meta", zap.Any("metadata", metadata), zap.Error(err))
+			return err
+		}
+		found := false
+		// recreate with the same spec does not change the rendered configuration of the pools
+		specChanged := true
+		for _, mirrorSet := range mirrorSetList.Items {
+			if mirrorSet.GetName() == metadata.Name {
+				zap.L().Debug("image mirror set exists", zap.String("kind", cfg.Kind), zap.String("name", metadata.Name))
+				found = true
+				if cfg.Config.Recreate {
+					zap.L().Debug("image mirror set recreate enabled", zap.String("kind", cfg.Kind), zap.String("name", metadata.Name))
+					specChanged = !mirrorSetAPI.IsSpecEqual(&mirrorSet, mirrorSetCfg)
+					err = mirrorSetAPI.Delete(k8sClient, &mirrorSet)
+					if err != nil {
+						return err
+					}
+					found = false
+				}
+				break
+			}
+		}
+		if !found {
+			err = mirrorSetAPI.CreateWithMap(k8sClient, cfg.Kind, mirrorSetCfg)
+			if err != nil {
+				zap.L().Error("error on creating an image mirror set", zap.String("kind", cfg.Kind), zap.String("name", metadata.Name), zap.Error(err))
+				return err
+			}
+			zap.L().Info("image mirror set created", zap.String("kind", cfg.Kind), zap.String("name", metadata.Name))
+			if specChanged {
+				changed = true
+			}
+		}
+	}
+
+	// without changes, waits only for the current rollout
+	if !changed {
+		snapshot = nil
+	}
+	return mcpAPI.WaitForRollout(k8sClient, nil, snapshot, cfg.Config.TimeoutConfig)
+}
@@ -0,0 +1,265 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	jobAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/job"
+	podAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/pod"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Run(k8sClient client.Client, clientSet kubernetes.Interface, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	cfg.Config.TimeoutConfig.UpdateJobDefaults()
+
+	switch cfg.Function {
+	case openshiftTY.FuncAdd:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return add(k8sClient, cfg)
+
+	case openshiftTY.FuncKeepOnly, openshiftTY.FuncRemove:
+		if len(cfg.Data) == 0 && !cfg.Config.Selector.IsDefined() {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		fallthrough
+	case openshiftTY.FuncRemoveAll:
+		return nil, performDelete(k8sClient, cfg)
+
+	case openshiftTY.FuncWaitForCompletion:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return waitForCompletion(k8sClient, cfg)
+
+	case openshiftTY.FuncGet:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return get(k8sClient, cfg)
+
+	case openshiftTY.FuncLogs:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return logs(k8sClient, clientSet, cfg)
+
+	}
+
+	return nil, fmt.Errorf("unknown function. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+}
+
+// waits for the jobs completion and returns the status of the jobs
+func waitForCompletion(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	suppliedItems := utils.ToNamespacedNameSlice(cfg.Data)
+
+	// group by namespace
+	items := map[string][]string{}
+	for _, job := range suppliedItems {
+		items[job.Namespace] = append(items[job.Namespace], job.Name)
+	}
+
+	// verify status
+	for namespace, jobs := range items {
+		err := jobAPI.WaitForCompletion(k8sClient, jobs, namespace, cfg.Config.TimeoutConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return get(k8sClient, cfg)
+}
+
+// returns the status of the jobs
+func get(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	results := make([]openshiftTY.JobStatus, 0)
+	for _, item := range utils.ToNamespacedNameSlice(cfg.Data) {
+		job, err := jobAPI.Get(k8sClient, item.Name, item.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, jobAPI.GetStatus(job))
+	}
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+// returns the logs of the last pod of the jobs
+func logs(k8sClient client.Client, clientSet kubernetes.Interface, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	results := make([]openshiftTY.LogsResult, 0)
+	for _, rawItem := range cfg.Data {
+		request := openshiftTY.LogsRequest{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &request)
+		if err != nil {
+			return nil, err
+		}
+
+		pods, err := jobAPI.ListPods(k8sClient, request.Name, request.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if len(pods) == 0 {
+			return nil, fmt.Errorf("no pods found for the job. name:%s, namespace:%s", request.Name, request.Namespace)
+		}
+		pod := &pods[len(pods)-1]
+
+		container := request.Container
+		if container == "" && len(pod.Spec.Containers) > 0 {
+			container = pod.Spec.Containers[0].Name
+		}
+		opts := &corev1.PodLogOptions{
+			Container:  container,
+			Timestamps: request.Timestamps,
+		}
+		if request.Tail > 0 {
+			tail := request.Tail
+			opts.TailLines = &tail
+		}
+
+		buffer := &bytes.Buffer{}
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Config.TimeoutConfig.Timeout)
+		err = podAPI.StreamLogs(ctx, clientSet, pod, opts, buffer)
+		cancel()
+		if err != nil {
+			zap.L().Error("error on getting logs", zap.String("job", request.Name), zap.String("pod", pod.Name), zap.String("namespace", pod.Namespace), zap.Error(err))
+			return nil, err
+		}
+		results = append(results, openshiftTY.LogsResult{Pod: pod.Name, Namespace: pod.Namespace, Container: container, Logs: buffer.String()})
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
+	}
+	jobList, err := jobAPI.List(k8sClient, opts)
+	if err != nil {
+		zap.L().Fatal("error on getting Job list", zap.Error(err))
+	}
+
+	// filter by namespace
+	selectedItems := make([]batchv1.Job, 0)
+	for _, job := range jobList.Items {
+		if cfg.Config.Selector.IsNamespaceSelected(job.Namespace) {
+			selectedItems = append(selectedItems, job)
+		}
+	}
+
+	if cfg.Function == openshiftTY.FuncRemoveAll {
+		return delete(k8sClient, cfg, selectedItems)
+	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
+		deletionList := make([]batchv1.Job, 0)
+
+		suppliedItems := utils.ToNamespacedNamePatterns(cfg.Data)
+
+		isRemove := cfg.Function == openshiftTY.FuncRemove
+
+		for _, job := range selectedItems {
+			if isRemove { // remove
+				if len(suppliedItems) == 0 || utils.MatchNamespacedName(suppliedItems, job.ObjectMeta) {
+					deletionList = append(deletionList, job)
+				}
+			} else { // keep only
+				if !utils.MatchNamespacedName(suppliedItems, job.ObjectMeta) {
+					deletionList = append(deletionList, job)
+				}
+			}
+		}
+
+		return delete(k8sClient, cfg, deletionList)
+	}
+	return nil
+}
+
+func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []batchv1.Job) error {
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	for _, job := range items {
+		err := jobAPI.Delete(k8sClient, &job)
+		if err != nil {
+			return err
+		}
+		zap.L().Debug("deleted a Job", zap.String("name", job.Name), zap.String("namespace", job.Namespace))
+	}
+	return nil
+}
+
+// creates the jobs and waits for the completion, unless no_wait is set
+func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	results := make([]openshiftTY.JobStatus, 0)
+	for _, cfgRaw := range cfg.Data {
+		jobCfg, ok := cfgRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		metadata, err := utils.GetObjectMeta(jobCfg)
+		if err != nil {
+			zap.L().Fatal("error on getting object meta", zap.Any("metadata", metadata), zap.Error(err))
+		}
+
+		job, err := jobAPI.Get(k8sClient, metadata.Name, metadata.Namespace)
+		if utils.IgnoreNotFoundError(err) != nil {
+			return nil, err
+		}
+		found := err == nil
+		if found {
+			zap.L().Debug("Job exists", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace))
+			if cfg.Config.Recreate {
+				zap.L().Debug("Job recreate enabled", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace))
+				err = jobAPI.Delete(k8sClient, job)
+				if err != nil {
+					return nil, err
+				}
+				err = jobAPI.WaitForDeletion(k8sClient, metadata.Name, metadata.Namespace, cfg.Config.TimeoutConfig)
+				if err != nil {
+					return nil, err
+				}
+				found = false
+			}
+		}
+		if !found {
+			err = jobAPI.CreateWithMap(k8sClient, jobCfg)
+			if err != nil {
+				zap.L().Fatal("error on creating Job", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace), zap.Error(err))
+			}
+			zap.L().Info("Job created", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace))
+		}
+
+		if !cfg.Config.NoWait {
+			err = jobAPI.WaitForCompletion(k8sClient, []string{metadata.Name}, metadata.Namespace, cfg.Config.TimeoutConfig)
+			if err != nil {
+				return nil, err
+			}
+		}
+		job, err = jobAPI.Get(k8sClient, metadata.Name, metadata.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, jobAPI.GetStatus(job))
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
@@ -0,0 +1,28 @@
+package task
+
+import (
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	mcpAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/machine_config_pool"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Run handles the machine config pools, data is the list of pool names, all the pools, if empty
+// wait_for_mcp waits for the current rollout, uses the rollout timeout defaults
+func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	switch cfg.Function {
+	case openshiftTY.FuncGet:
+		return mcpAPI.ListStatus(k8sClient, utils.ToStringSlice(cfg.Data))
+
+	case openshiftTY.FuncWaitForMCP:
+		names := utils.ToStringSlice(cfg.Data)
+		err := mcpAPI.WaitForRollout(k8sClient, names, nil, cfg.Config.TimeoutConfig)
+		if err != nil {
+			return nil, err
+		}
+		return mcpAPI.ListStatus(k8sClient, names)
+
+	}
+
+	return nil, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/jkandasa/autoeasy/pkg/utils"
 	nsAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/namespace"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -30,30 +31,39 @@ func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{},
 }
 
 func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
-	opts := []client.ListOption{
-		client.InNamespace(""),
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
 	}
 	nsList, err := nsAPI.List(k8sClient, opts)
 	if err != nil {
 		zap.L().Fatal("error on getting Namespace list", zap.Error(err))
 	}
 
+	// filter by namespace
+	selectedItems := make([]corev1.Namespace, 0)
+	for _, ns := range nsList.Items {
+		if cfg.Config.Selector.IsNamespaceSelected(ns.Name) {
+			selectedItems = append(selectedItems, ns)
+		}
+	}
+
 	if cfg.Function == openshiftTY.FuncRemoveAll {
-		return delete(k8sClient, cfg, nsList.Items)
-	} else if cfg.Function == openshiftTY.FuncRemoveAll || cfg.Function == openshiftTY.FuncKeepOnly {
+		return delete(k8sClient, cfg, selectedItems)
+	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
 		deletionList := make([]corev1.Namespace, 0)
 
 		suppliedItems := utils.ToStringSlice(cfg.Data)
 
 		isRemove := cfg.Function == openshiftTY.FuncRemove
 
-		for _, ns := range nsList.Items {
+		for _, ns := range selectedItems {
 			if isRemove { // remove
-				if utils.ContainsString(suppliedItems, ns.Name) {
+				if len(suppliedItems) == 0 || utils.MatchString(suppliedItems, ns.Name) {
 					deletionList = append(deletionList, ns)
 				}
 			} else { // keep only
-				if !utils.ContainsString(suppliedItems, ns.Name) {
+				if !utils.MatchString(suppliedItems, ns.Name) {
 					deletionList = append(deletionList, ns)
 				}
 			}
@@ -66,8 +76,9 @@ func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) err
 }
 
 func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []corev1.Namespace) error {
-	if len(items) == 0 {
-		return nil
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
 	}
 	namespaces := make([]string, len(items))
 	for index, ns := range items {
@@ -116,6 +127,10 @@ func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
 				found = true
 				if cfg.Config.Recreate {
 					zap.L().Debug("Namespace recreate enabled", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace))
+					// guard skips the protected namespace, can not be recreated
+					if cfg.Config.Protection.IsProtectedNamespace(ns.Name) {
+						return fmt.Errorf("protected namespace can not be recreated. name:%s", ns.Name)
+					}
 					err = delete(k8sClient, cfg, []corev1.Namespace{ns})
 					if err != nil {
 						return err
@@ -128,7 +143,8 @@ func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
 		if !found {
 			err = nsAPI.CreateWithMap(k8sClient, nsCfg)
 			if err != nil {
-				zap.L().Fatal("error on creating Namespace", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace), zap.Error(err))
+				zap.L().Error("error on creating Namespace", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace), zap.Error(err))
+				return err
 			}
 			zap.L().Info("Namespace created", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace))
 		}
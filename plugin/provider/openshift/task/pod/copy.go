@@ -0,0 +1,64 @@
+package task
+
+import (
+	"context"
+
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	podAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/pod"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// copies files to or from the pods, based on the function
+func copyFiles(k8sClient client.Client, clientSet kubernetes.Interface, restConfig *rest.Config, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	results := make([]openshiftTY.CopyResult, 0)
+	for _, rawItem := range cfg.Data {
+		request := openshiftTY.CopyRequest{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &request)
+		if err != nil {
+			return nil, err
+		}
+		updatePodSelector(&request.PodSelector, cfg.Kind)
+
+		pods, err := selectPods(k8sClient, request.PodSelector, true)
+		if err != nil {
+			return nil, err
+		}
+		pod := &pods[0]
+		container := request.Container
+		if container == "" && len(pod.Spec.Containers) > 0 {
+			container = pod.Spec.Containers[0].Name
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Config.TimeoutConfig.Timeout)
+		filesCount := 0
+		if cfg.Function == openshiftTY.FuncCopyToPod {
+			filesCount, err = podAPI.CopyToPod(ctx, clientSet, restConfig, pod, container, request.Source, request.Destination)
+		} else {
+			filesCount, err = podAPI.CopyFromPod(ctx, clientSet, restConfig, pod, container, request.Source, request.Destination)
+		}
+		cancel()
+		if err != nil {
+			zap.L().Error("error on copying files", zap.String("function", cfg.Function), zap.String("pod", pod.Name), zap.String("namespace", pod.Namespace), zap.String("src", request.Source), zap.String("dest", request.Destination), zap.Error(err))
+			return nil, err
+		}
+		zap.L().Info("files copied", zap.String("function", cfg.Function), zap.String("pod", pod.Name), zap.String("namespace", pod.Namespace), zap.String("src", request.Source), zap.String("dest", request.Destination), zap.Int("files", filesCount))
+
+		results = append(results, openshiftTY.CopyResult{
+			Pod:         pod.Name,
+			Namespace:   pod.Namespace,
+			Container:   container,
+			Source:      request.Source,
+			Destination: request.Destination,
+			Files:       filesCount,
+		})
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
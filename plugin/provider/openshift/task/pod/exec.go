@@ -0,0 +1,234 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	deploymentAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/deployment"
+	podAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/pod"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func exec(k8sClient client.Client, clientSet kubernetes.Interface, restConfig *rest.Config, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	results := make([]openshiftTY.ExecResult, 0)
+	for _, rawItem := range cfg.Data {
+		request := openshiftTY.ExecRequest{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &request)
+		if err != nil {
+			return nil, err
+		}
+		updatePodSelector(&request.PodSelector, cfg.Kind)
+
+		pods, err := selectPods(k8sClient, request.PodSelector, true)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Config.TimeoutConfig.Timeout)
+		result, err := podAPI.Exec(ctx, clientSet, restConfig, &pods[0], request.Container, request.Command, request.Stdin)
+		cancel()
+		if err != nil {
+			zap.L().Error("error on executing a command", zap.String("pod", pods[0].Name), zap.String("namespace", pods[0].Namespace), zap.Any("command", request.Command), zap.Error(err))
+			return nil, err
+		}
+		zap.L().Debug("executed a command", zap.Any("command", request.Command), zap.Any("result", result))
+
+		results = append(results, *result)
+		if result.ExitCode != 0 && !request.IgnoreExitCode {
+			return results, fmt.Errorf("command exited with non-zero code. exitCode:%d, pod:%s, namespace:%s, stderr:%s", result.ExitCode, result.Pod, result.Namespace, result.Stderr)
+		}
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+func logs(k8sClient client.Client, clientSet kubernetes.Interface, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	results := make([]openshiftTY.LogsResult, 0)
+	for _, rawItem := range cfg.Data {
+		request := openshiftTY.LogsRequest{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &request)
+		if err != nil {
+			return nil, err
+		}
+		updatePodSelector(&request.PodSelector, cfg.Kind)
+
+		pods, err := selectPods(k8sClient, request.PodSelector, false)
+		if err != nil {
+			return nil, err
+		}
+
+		// open the file, if supplied
+		var file *os.File
+		if request.File != "" {
+			err = os.MkdirAll(filepath.Dir(request.File), os.ModePerm)
+			if err != nil {
+				return nil, err
+			}
+			file, err = os.Create(request.File)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for index := range pods {
+			pod := &pods[index]
+			containers := []string{request.Container}
+			if request.AllContainers {
+				containers = make([]string, 0)
+				for _, container := range pod.Spec.Containers {
+					containers = append(containers, container.Name)
+				}
+			} else if request.Container == "" && len(pod.Spec.Containers) > 0 {
+				containers = []string{pod.Spec.Containers[0].Name}
+			}
+
+			for _, container := range containers {
+				result, err := fetchLogs(clientSet, cfg, request, pod, container, file)
+				if err != nil {
+					if file != nil {
+						file.Close()
+					}
+					zap.L().Error("error on getting logs", zap.String("pod", pod.Name), zap.String("namespace", pod.Namespace), zap.String("container", container), zap.Error(err))
+					return nil, err
+				}
+				results = append(results, *result)
+			}
+		}
+
+		if file != nil {
+			err = file.Close()
+			if err != nil {
+				return nil, err
+			}
+			zap.L().Info("logs written to a file", zap.String("file", request.File))
+		}
+	}
+	return results, nil
+}
+
+func fetchLogs(clientSet kubernetes.Interface, cfg *openshiftTY.ProviderConfig, request openshiftTY.LogsRequest, pod *corev1.Pod, container string, file *os.File) (*openshiftTY.LogsResult, error) {
+	opts := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     request.Follow,
+		Previous:   request.Previous,
+		Timestamps: request.Timestamps,
+	}
+	if request.Since > 0 {
+		sinceSeconds := int64(request.Since.Seconds())
+		opts.SinceSeconds = &sinceSeconds
+	}
+	if request.Tail > 0 {
+		tail := request.Tail
+		opts.TailLines = &tail
+	}
+
+	result := &openshiftTY.LogsResult{Pod: pod.Name, Namespace: pod.Namespace, Container: container}
+
+	var writer io.Writer
+	buffer := &bytes.Buffer{}
+	if file != nil {
+		_, err := fmt.Fprintf(file, "==> %s/%s/%s <==\n", pod.Namespace, pod.Name, container)
+		if err != nil {
+			return nil, err
+		}
+		writer = file
+		result.File = file.Name()
+	} else {
+		writer = buffer
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Config.TimeoutConfig.Timeout)
+	defer cancel()
+	err := podAPI.StreamLogs(ctx, clientSet, pod, opts, writer)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		result.Logs = buffer.String()
+	}
+	return result, nil
+}
+
+// updates the deployment name from the name, on deployment kind
+func updatePodSelector(selector *openshiftTY.PodSelector, kind string) {
+	if kind == openshiftTY.KindDeployment && selector.Deployment == "" {
+		selector.Deployment = selector.Name
+		selector.Name = ""
+	}
+}
+
+// returns the pods of the selector
+// on runningOnly, returns only the running pods of the deployment or label selector
+func selectPods(k8sClient client.Client, selector openshiftTY.PodSelector, runningOnly bool) ([]corev1.Pod, error) {
+	if selector.Namespace == "" {
+		return nil, fmt.Errorf("namespace can not be empty. %+v", selector)
+	}
+
+	var pods []corev1.Pod
+	switch {
+	case selector.Name != "":
+		pod, err := podAPI.Get(k8sClient, selector.Name, selector.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		return []corev1.Pod{*pod}, nil
+
+	case selector.Deployment != "":
+		podList, err := deploymentAPI.ListPods(k8sClient, selector.Deployment, selector.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range podList.Items {
+			if pod.Namespace == selector.Namespace {
+				pods = append(pods, pod)
+			}
+		}
+
+	case selector.LabelSelector != "":
+		labelSelector, err := labels.Parse(selector.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		opts := []client.ListOption{
+			client.InNamespace(selector.Namespace),
+			client.MatchingLabelsSelector{Selector: labelSelector},
+		}
+		podList, err := podAPI.List(k8sClient, opts)
+		if err != nil {
+			return nil, err
+		}
+		pods = podList.Items
+
+	default:
+		return nil, fmt.Errorf("name, deployment or label_selector is required. %+v", selector)
+	}
+
+	if runningOnly {
+		runningPods := make([]corev1.Pod, 0)
+		for _, pod := range pods {
+			if pod.Status.Phase == corev1.PodRunning {
+				runningPods = append(runningPods, pod)
+			}
+		}
+		pods = runningPods
+	}
+
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no pods found. %+v", selector)
+	}
+	return pods, nil
+}
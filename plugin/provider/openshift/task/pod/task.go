@@ -5,13 +5,16 @@ import (
 
 	"github.com/jkandasa/autoeasy/pkg/utils"
 	podAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/pod"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+func Run(k8sClient client.Client, clientSet kubernetes.Interface, restConfig *rest.Config, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
 	switch cfg.Function {
 	case openshiftTY.FuncAdd:
 		if len(cfg.Data) == 0 {
@@ -20,7 +23,7 @@ func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{},
 		return nil, add(k8sClient, cfg)
 
 	case openshiftTY.FuncKeepOnly, openshiftTY.FuncRemove:
-		if len(cfg.Data) == 0 {
+		if len(cfg.Data) == 0 && !cfg.Config.Selector.IsDefined() {
 			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
 		}
 		fallthrough
@@ -33,6 +36,23 @@ func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{},
 		}
 		return nil, waitForReady(k8sClient, cfg)
 
+	case openshiftTY.FuncExec, openshiftTY.FuncLogs:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		cfg.Config.TimeoutConfig.UpdateDefaults()
+		if cfg.Function == openshiftTY.FuncExec {
+			return exec(k8sClient, clientSet, restConfig, cfg)
+		}
+		return logs(k8sClient, clientSet, cfg)
+
+	case openshiftTY.FuncCopyToPod, openshiftTY.FuncCopyFromPod:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		cfg.Config.TimeoutConfig.UpdateDefaults()
+		return copyFiles(k8sClient, clientSet, restConfig, cfg)
+
 	}
 
 	return nil, fmt.Errorf("unknown function. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
@@ -64,30 +84,39 @@ func waitForReady(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) erro
 }
 
 func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
-	opts := []client.ListOption{
-		client.InNamespace(""),
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
 	}
 	podList, err := podAPI.List(k8sClient, opts)
 	if err != nil {
 		zap.L().Fatal("error on getting pod list", zap.Error(err))
 	}
 
+	// filter by namespace
+	selectedItems := make([]corev1.Pod, 0)
+	for _, pod := range podList.Items {
+		if cfg.Config.Selector.IsNamespaceSelected(pod.Namespace) {
+			selectedItems = append(selectedItems, pod)
+		}
+	}
+
 	if cfg.Function == openshiftTY.FuncRemoveAll {
-		return delete(k8sClient, cfg, podList.Items)
+		return delete(k8sClient, cfg, selectedItems)
 	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
 		deletionList := make([]corev1.Pod, 0)
 
-		suppliedItems := utils.ToNamespacedNameSlice(cfg.Data)
+		suppliedItems := utils.ToNamespacedNamePatterns(cfg.Data)
 
 		isRemove := cfg.Function == openshiftTY.FuncRemove
 
-		for _, pod := range podList.Items {
+		for _, pod := range selectedItems {
 			if isRemove { // remove
-				if utils.ContainsNamespacedName(suppliedItems, pod.ObjectMeta) {
+				if len(suppliedItems) == 0 || utils.MatchNamespacedName(suppliedItems, pod.ObjectMeta) {
 					deletionList = append(deletionList, pod)
 				}
 			} else { // keep only
-				if utils.ContainsNamespacedName(suppliedItems, pod.ObjectMeta) {
+				if !utils.MatchNamespacedName(suppliedItems, pod.ObjectMeta) {
 					deletionList = append(deletionList, pod)
 				}
 			}
@@ -100,8 +129,9 @@ func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) err
 }
 
 func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []corev1.Pod) error {
-	if len(items) == 0 {
-		return nil
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
 	}
 	for _, pod := range items {
 		err := podAPI.Delete(k8sClient, &pod)
@@ -0,0 +1,200 @@
+package task
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	dataRepoSVC "github.com/jkandasa/autoeasy/pkg/service/data_repository"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	portForwardAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/port_forward"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	"k8s.io/client-go/rest"
+)
+
+// key prefix of the port forwards on the data repository
+const dataRepoKeyPrefix = "port_forward"
+
+type activeForward struct {
+	result         openshiftTY.PortForwardResult
+	closeFunc      func()
+	remainingTasks int
+	counting       bool // the task opened the forward is not counted
+}
+
+// Store holds the active port forwards of a provider instance
+type Store struct {
+	forwards map[string]*activeForward
+	mutex    sync.Mutex
+}
+
+func NewStore() *Store {
+	return &Store{forwards: map[string]*activeForward{}}
+}
+
+func (s *Store) Run(restConfig *rest.Config, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	switch cfg.Function {
+	case openshiftTY.FuncAdd:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return s.add(restConfig, cfg)
+
+	case openshiftTY.FuncClose:
+		return nil, s.closeForwards(cfg)
+
+	case openshiftTY.FuncGet:
+		return s.get(), nil
+	}
+
+	return nil, fmt.Errorf("unknown function. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+}
+
+func (s *Store) add(restConfig *rest.Config, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	results := make([]openshiftTY.PortForwardResult, 0)
+	for _, rawItem := range cfg.Data {
+		data := openshiftTY.PortForwardData{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &data)
+		if err != nil {
+			return nil, err
+		}
+		if data.Name == "" {
+			data.Name = getDefaultName(data.PortForwardRequest)
+		}
+		if _, found := s.forwards[data.Name]; found {
+			return nil, fmt.Errorf("port forward already active. name:%s", data.Name)
+		}
+
+		closeFunc, ports, err := portForwardAPI.Start(restConfig, data.PortForwardRequest)
+		if err != nil {
+			zap.L().Error("error on starting port forward", zap.String("name", data.Name), zap.Error(err))
+			return nil, err
+		}
+
+		result := openshiftTY.PortForwardResult{
+			Name:      data.Name,
+			Namespace: data.Namespace,
+			Pod:       data.Pod,
+			Ports:     ports,
+		}
+		if len(ports) > 0 {
+			host := "127.0.0.1"
+			if len(data.Addresses) > 0 {
+				host = data.Addresses[0]
+			}
+			result.Address = net.JoinHostPort(host, strconv.Itoa(int(ports[0].Local)))
+		}
+
+		s.forwards[data.Name] = &activeForward{result: result, closeFunc: closeFunc, remainingTasks: data.CloseAfter}
+		dataRepoSVC.Add(getDataRepoKey(data.Name), result)
+		zap.L().Info("port forward opened", zap.String("name", data.Name), zap.String("address", result.Address), zap.Int("closeAfter", data.CloseAfter))
+		results = append(results, result)
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+// closes the port forwards of the supplied names, closes all on empty data
+func (s *Store) closeForwards(cfg *openshiftTY.ProviderConfig) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(cfg.Data) == 0 {
+		for name := range s.forwards {
+			s.closeForward(name)
+		}
+		return nil
+	}
+
+	for _, rawItem := range cfg.Data {
+		name, ok := rawItem.(string)
+		if !ok {
+			data := openshiftTY.PortForwardData{}
+			err := formatterUtils.YamlInterfaceToStruct(rawItem, &data)
+			if err != nil {
+				return err
+			}
+			name = data.Name
+		}
+		if _, found := s.forwards[name]; !found {
+			zap.L().Warn("port forward not active", zap.String("name", name))
+			continue
+		}
+		s.closeForward(name)
+	}
+	return nil
+}
+
+func (s *Store) get() []openshiftTY.PortForwardResult {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	results := make([]openshiftTY.PortForwardResult, 0)
+	for _, forward := range s.forwards {
+		results = append(results, forward.result)
+	}
+	return results
+}
+
+// TaskCompleted updates the remaining tasks count of the port forwards
+// and closes the port forwards reached the "close_after" limit
+// called once per task by the provider owns the store, tasks are executed sequentially
+func (s *Store) TaskCompleted() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for name, forward := range s.forwards {
+		if forward.remainingTasks <= 0 {
+			continue
+		}
+		if !forward.counting {
+			forward.counting = true
+			continue
+		}
+		forward.remainingTasks--
+		if forward.remainingTasks == 0 {
+			s.closeForward(name)
+		}
+	}
+}
+
+// CloseAll closes all the active port forwards of the store
+func (s *Store) CloseAll() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for name := range s.forwards {
+		s.closeForward(name)
+	}
+}
+
+// should be called with lock
+func (s *Store) closeForward(name string) {
+	forward := s.forwards[name]
+	forward.closeFunc()
+	delete(s.forwards, name)
+	dataRepoSVC.Delete(getDataRepoKey(name))
+	zap.L().Info("port forward closed", zap.String("name", name), zap.String("address", forward.result.Address))
+}
+
+func getDefaultName(request openshiftTY.PortForwardRequest) string {
+	switch {
+	case request.Service != "":
+		return request.Service
+	case request.Deployment != "":
+		return request.Deployment
+	}
+	return request.Pod
+}
+
+func getDataRepoKey(name string) string {
+	return fmt.Sprintf("%s.%s", dataRepoKeyPrefix, name)
+}
@@ -0,0 +1,85 @@
+package task
+
+import (
+	"testing"
+)
+
+// adds a forward without the port forward connection
+func addTestForward(store *Store, name string, closeAfter int, closed map[string]bool) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.forwards[name] = &activeForward{closeFunc: func() { closed[name] = true }, remainingTasks: closeAfter}
+}
+
+func TestTaskCompletedCloseAfter(t *testing.T) {
+	closed := map[string]bool{}
+	store := NewStore()
+	addTestForward(store, "one", 1, closed)
+	addTestForward(store, "two", 2, closed)
+	addTestForward(store, "keep", 0, closed)
+	defer store.CloseAll()
+
+	// completion of the task opened the forwards
+	store.TaskCompleted()
+	if len(closed) != 0 {
+		t.Fatalf("forwards closed on the opening task: %v", closed)
+	}
+
+	store.TaskCompleted()
+	if !closed["one"] || closed["two"] {
+		t.Fatalf("expected only 'one' to be closed: %v", closed)
+	}
+
+	store.TaskCompleted()
+	if !closed["two"] {
+		t.Fatalf("expected 'two' to be closed: %v", closed)
+	}
+
+	for i := 0; i < 5; i++ {
+		store.TaskCompleted()
+	}
+	if closed["keep"] {
+		t.Fatalf("forward without close_after should be kept")
+	}
+}
+
+func TestTaskCompletedTwoProviders(t *testing.T) {
+	// stores of two provider instances, example: hub and spoke
+	hubClosed := map[string]bool{}
+	spokeClosed := map[string]bool{}
+	hub := NewStore()
+	spoke := NewStore()
+	addTestForward(hub, "db", 3, hubClosed)
+	addTestForward(spoke, "db", 3, spokeClosed)
+
+	// every task notifies both the providers
+	taskCompleted := func() {
+		hub.TaskCompleted()
+		spoke.TaskCompleted()
+	}
+
+	// opening task and two more tasks
+	for i := 0; i < 3; i++ {
+		taskCompleted()
+	}
+	if hubClosed["db"] || spokeClosed["db"] {
+		t.Fatalf("forwards closed before the close_after limit. hub:%v, spoke:%v", hubClosed, spokeClosed)
+	}
+
+	taskCompleted()
+	if !hubClosed["db"] || !spokeClosed["db"] {
+		t.Fatalf("forwards not closed on the close_after limit. hub:%v, spoke:%v", hubClosed, spokeClosed)
+	}
+
+	// closing a provider keeps the forwards of the other provider
+	addTestForward(hub, "cache", 0, hubClosed)
+	addTestForward(spoke, "cache", 0, spokeClosed)
+	hub.CloseAll()
+	if !hubClosed["cache"] || spokeClosed["cache"] {
+		t.Fatalf("close of a provider affected the other provider. hub:%v, spoke:%v", hubClosed, spokeClosed)
+	}
+	if len(spoke.get()) != 1 {
+		t.Fatalf("expected the spoke forward to be active")
+	}
+	spoke.CloseAll()
+}
@@ -0,0 +1,360 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/jkandasa/autoeasy/pkg/json"
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	resourceAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/resource"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Run(k8sClient client.Client, dynamicClient dynamic.Interface, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	if len(cfg.Data) == 0 {
+		return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+	}
+	cfg.Config.TimeoutConfig.UpdateDefaults()
+
+	switch cfg.Function {
+	case openshiftTY.FuncAdd:
+		return nil, add(k8sClient, dynamicClient, cfg)
+
+	case openshiftTY.FuncGet:
+		return get(k8sClient, dynamicClient, cfg)
+
+	case openshiftTY.FuncApply:
+		return apply(k8sClient, dynamicClient, cfg)
+
+	case openshiftTY.FuncPatch:
+		return patch(k8sClient, dynamicClient, cfg)
+
+	case openshiftTY.FuncRemove, openshiftTY.FuncRemoveAll, openshiftTY.FuncKeepOnly:
+		return nil, performDelete(k8sClient, dynamicClient, cfg)
+
+	case openshiftTY.FuncWaitForReady:
+		items, err := toResourceRefs(cfg.Data)
+		if err != nil {
+			return nil, err
+		}
+		return nil, resourceAPI.WaitForReady(k8sClient, dynamicClient, items, cfg.Config.TimeoutConfig)
+
+	case openshiftTY.FuncWaitForCondition:
+		return nil, waitForCondition(k8sClient, dynamicClient, cfg)
+
+	case openshiftTY.FuncWaitForDelete:
+		items, err := toResourceRefs(cfg.Data)
+		if err != nil {
+			return nil, err
+		}
+		return nil, resourceAPI.WaitForDeletion(k8sClient, dynamicClient, items, cfg.Config.TimeoutConfig)
+	}
+
+	return nil, fmt.Errorf("unknown function. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+}
+
+func add(k8sClient client.Client, dynamicClient dynamic.Interface, cfg *openshiftTY.ProviderConfig) error {
+	for _, cfgRaw := range cfg.Data {
+		resourceCfg, ok := cfgRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		resource, err := resourceAPI.ToUnstructured(resourceCfg)
+		if err != nil {
+			return err
+		}
+		ref := toResourceRef(resource)
+
+		existing, err := resourceAPI.Get(k8sClient, dynamicClient, ref.APIVersion, ref.Kind, ref.Name, ref.Namespace)
+		if err == nil && existing != nil {
+			zap.L().Debug("resource exists", zap.Any("resource", ref))
+			if !cfg.Config.Recreate {
+				continue
+			}
+			zap.L().Debug("resource recreate enabled", zap.Any("resource", ref))
+			err = deleteResources(k8sClient, dynamicClient, cfg, []openshiftTY.ResourceRef{ref})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = resourceAPI.Create(k8sClient, dynamicClient, resource)
+		if err != nil {
+			zap.L().Error("error on creating resource", zap.Any("resource", ref), zap.Error(err))
+			return err
+		}
+		zap.L().Info("resource created", zap.String("apiVersion", ref.APIVersion), zap.String("kind", ref.Kind), zap.String("name", ref.Name), zap.String("namespace", ref.Namespace))
+	}
+	return nil
+}
+
+func apply(k8sClient client.Client, dynamicClient dynamic.Interface, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	result := make([]interface{}, 0)
+	for _, cfgRaw := range cfg.Data {
+		resourceCfg, ok := cfgRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		resource, err := resourceAPI.ToUnstructured(resourceCfg)
+		if err != nil {
+			return nil, err
+		}
+		updateTypeMeta(resource, cfg.Kind)
+		ref := toResourceRef(resource)
+
+		applied, err := resourceAPI.Apply(k8sClient, dynamicClient, resource, cfg.Config.FieldManager, cfg.Config.ForceConflicts)
+		if err != nil {
+			zap.L().Error("error on applying resource", zap.Any("resource", ref), zap.Error(err))
+			return nil, err
+		}
+		zap.L().Info("resource applied", zap.String("apiVersion", ref.APIVersion), zap.String("kind", ref.Kind), zap.String("name", ref.Name), zap.String("namespace", ref.Namespace))
+		result = append(result, applied.Object)
+	}
+	return result, nil
+}
+
+func patch(k8sClient client.Client, dynamicClient dynamic.Interface, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	result := make([]interface{}, 0)
+	for _, rawItem := range cfg.Data {
+		item := openshiftTY.PatchData{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &item)
+		if err != nil {
+			return nil, err
+		}
+		updateResourceRef(&item.ResourceRef, cfg.Kind)
+		if item.Name == "" || item.Patch == nil {
+			return nil, fmt.Errorf("name and patch are required. %+v", item.ResourceRef)
+		}
+
+		// patch can be supplied as a raw string or as an object
+		var patchBytes []byte
+		if patchString, ok := item.Patch.(string); ok {
+			patchBytes = []byte(patchString)
+		} else {
+			patchBytes, err = json.Marshal(item.Patch)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		patched, err := resourceAPI.Patch(k8sClient, dynamicClient, item.ResourceRef, item.Type, patchBytes, cfg.Config.FieldManager)
+		if err != nil {
+			zap.L().Error("error on patching resource", zap.Any("resource", item.ResourceRef), zap.String("type", item.Type), zap.Error(err))
+			return nil, err
+		}
+		zap.L().Info("resource patched", zap.String("apiVersion", item.APIVersion), zap.String("kind", item.Kind), zap.String("name", item.Name), zap.String("namespace", item.Namespace), zap.String("type", item.Type))
+		result = append(result, patched.Object)
+	}
+	return result, nil
+}
+
+func waitForCondition(k8sClient client.Client, dynamicClient dynamic.Interface, cfg *openshiftTY.ProviderConfig) error {
+	items := make([]openshiftTY.ConditionData, 0)
+	for _, rawItem := range cfg.Data {
+		item := openshiftTY.ConditionData{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &item)
+		if err != nil {
+			return err
+		}
+		updateResourceRef(&item.ResourceRef, cfg.Kind)
+		if item.Name == "" {
+			return fmt.Errorf("name is required. %+v", item.ResourceRef)
+		}
+		if item.Condition == nil && item.JSONPath == "" && item.Compare == "" {
+			return fmt.Errorf("condition, jsonpath or compare is required. %+v", item.ResourceRef)
+		}
+		items = append(items, item)
+	}
+	return resourceAPI.WaitForCondition(k8sClient, dynamicClient, items, cfg.Config.TimeoutConfig)
+}
+
+// kindAPIVersions used to update the apiVersion, when the task kind is a known kind
+var kindAPIVersions = map[string]string{
+	openshiftTY.KindNamespace:                "v1",
+	openshiftTY.KindPod:                      "v1",
+	openshiftTY.KindDeployment:               "apps/v1",
+	openshiftTY.KindRoute:                    "route.openshift.io/v1",
+	openshiftTY.KindSubscription:             "operators.coreos.com/v1alpha1",
+	openshiftTY.KindCatalogSource:            "operators.coreos.com/v1alpha1",
+	openshiftTY.KindImageContentSourcePolicy: "operator.openshift.io/v1alpha1",
+}
+
+// updates the missing apiVersion and kind from the task kind
+func updateTypeMeta(resource *unstructured.Unstructured, kind string) {
+	if resource.GetKind() == "" && kind != openshiftTY.KindResource {
+		resource.SetKind(kind)
+	}
+	if resource.GetAPIVersion() == "" {
+		resource.SetAPIVersion(kindAPIVersions[resource.GetKind()])
+	}
+}
+
+// updates the missing apiVersion and kind from the task kind
+func updateResourceRef(ref *openshiftTY.ResourceRef, kind string) {
+	if ref.Kind == "" && kind != openshiftTY.KindResource {
+		ref.Kind = kind
+	}
+	if ref.APIVersion == "" {
+		ref.APIVersion = kindAPIVersions[ref.Kind]
+	}
+}
+
+func get(k8sClient client.Client, dynamicClient dynamic.Interface, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	items, err := toResourceRefs(cfg.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0)
+	for _, item := range items {
+		// without name, returns all the resources of the kind
+		if item.Name == "" {
+			resourceList, err := resourceAPI.List(k8sClient, dynamicClient, item.APIVersion, item.Kind, item.Namespace, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			for _, resource := range resourceList.Items {
+				result = append(result, resource.Object)
+			}
+			continue
+		}
+		resource, err := resourceAPI.Get(k8sClient, dynamicClient, item.APIVersion, item.Kind, item.Name, item.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resource.Object)
+	}
+
+	if len(items) == 1 && items[0].Name != "" && len(result) == 1 {
+		return result[0], nil
+	}
+	return result, nil
+}
+
+func performDelete(k8sClient client.Client, dynamicClient dynamic.Interface, cfg *openshiftTY.ProviderConfig) error {
+	suppliedItems, err := toResourceRefs(cfg.Data)
+	if err != nil {
+		return err
+	}
+
+	// group by apiVersion, kind and namespace
+	// namespace patterns are listed from all the namespaces
+	groups := map[string][]openshiftTY.ResourceRef{}
+	groupKeys := []string{}
+	for _, item := range suppliedItems {
+		listNamespace := item.Namespace
+		if utils.IsPattern(listNamespace) {
+			listNamespace = ""
+		}
+		key := fmt.Sprintf("%s|%s|%s", item.APIVersion, item.Kind, listNamespace)
+		if _, ok := groups[key]; !ok {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	selector := cfg.Config.Selector
+	listOpts := metav1.ListOptions{LabelSelector: selector.Labels, FieldSelector: selector.Fields}
+
+	deletionList := make([]openshiftTY.ResourceRef, 0)
+	for _, key := range groupKeys {
+		items := groups[key]
+		ref := items[0]
+		listNamespace := ref.Namespace
+		if utils.IsPattern(listNamespace) {
+			listNamespace = ""
+		}
+		resourceList, err := resourceAPI.List(k8sClient, dynamicClient, ref.APIVersion, ref.Kind, listNamespace, listOpts)
+		if err != nil {
+			zap.L().Error("error on getting resource list", zap.Any("resource", ref), zap.Error(err))
+			return err
+		}
+
+		for index := range resourceList.Items {
+			resource := toResourceRef(&resourceList.Items[index])
+			if !selector.IsNamespaceSelected(resource.Namespace) {
+				continue
+			}
+			switch cfg.Function {
+			case openshiftTY.FuncRemove:
+				if !matchResource(items, resource) {
+					continue
+				}
+			case openshiftTY.FuncKeepOnly:
+				if matchResource(items, resource) {
+					continue
+				}
+			}
+			deletionList = append(deletionList, resource)
+		}
+	}
+
+	return deleteResources(k8sClient, dynamicClient, cfg, deletionList)
+}
+
+func deleteResources(k8sClient client.Client, dynamicClient dynamic.Interface, cfg *openshiftTY.ProviderConfig, items []openshiftTY.ResourceRef) error {
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	for _, item := range items {
+		err := resourceAPI.Delete(k8sClient, dynamicClient, item.APIVersion, item.Kind, item.Name, item.Namespace)
+		if err != nil {
+			return err
+		}
+		zap.L().Debug("deleted a resource", zap.Any("resource", item))
+	}
+
+	// wait for absent
+	tc := cfg.Config.TimeoutConfig
+	tc.ExpectedSuccessCount = 1
+	return resourceAPI.WaitForDeletion(k8sClient, dynamicClient, items, tc)
+}
+
+// returns true, if any of the items matches the target
+// name and namespace can be glob or regular expression, empty value matches all
+func matchResource(items []openshiftTY.ResourceRef, target openshiftTY.ResourceRef) bool {
+	for _, item := range items {
+		if item.Name != "" && !utils.MatchPattern(item.Name, target.Name) {
+			continue
+		}
+		if item.Namespace != "" && !utils.MatchPattern(item.Namespace, target.Namespace) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func toResourceRef(resource *unstructured.Unstructured) openshiftTY.ResourceRef {
+	return openshiftTY.ResourceRef{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Name:       resource.GetName(),
+		Namespace:  resource.GetNamespace(),
+	}
+}
+
+func toResourceRefs(rawItems []interface{}) ([]openshiftTY.ResourceRef, error) {
+	items := make([]openshiftTY.ResourceRef, 0)
+	for _, rawItem := range rawItems {
+		item := openshiftTY.ResourceRef{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &item)
+		if err != nil {
+			return nil, err
+		}
+		if item.APIVersion == "" || item.Kind == "" {
+			return nil, fmt.Errorf("apiVersion and kind are required. %+v", item)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
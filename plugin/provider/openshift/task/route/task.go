@@ -6,6 +6,7 @@ import (
 
 	"github.com/jkandasa/autoeasy/pkg/utils"
 	routeAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/route"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	osroutev1 "github.com/openshift/api/route/v1"
 	"go.uber.org/zap"
@@ -21,7 +22,7 @@ func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{},
 		return nil, add(k8sClient, cfg)
 
 	case openshiftTY.FuncKeepOnly, openshiftTY.FuncRemove:
-		if len(cfg.Data) == 0 {
+		if len(cfg.Data) == 0 && !cfg.Config.Selector.IsDefined() {
 			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
 		}
 		fallthrough
@@ -50,30 +51,39 @@ func get(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{},
 }
 
 func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
-	opts := []client.ListOption{
-		client.InNamespace(""),
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
 	}
 	routeList, err := routeAPI.List(k8sClient, opts)
 	if err != nil {
 		zap.L().Fatal("error on getting Route list", zap.Error(err))
 	}
 
+	// filter by namespace
+	selectedItems := make([]osroutev1.Route, 0)
+	for _, route := range routeList.Items {
+		if cfg.Config.Selector.IsNamespaceSelected(route.Namespace) {
+			selectedItems = append(selectedItems, route)
+		}
+	}
+
 	if cfg.Function == openshiftTY.FuncRemoveAll {
-		return delete(k8sClient, cfg, routeList.Items)
+		return delete(k8sClient, cfg, selectedItems)
 	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
 		deletionList := make([]osroutev1.Route, 0)
 
-		suppliedItems := utils.ToNamespacedNameSlice(cfg.Data)
+		suppliedItems := utils.ToNamespacedNamePatterns(cfg.Data)
 
 		isRemove := cfg.Function == openshiftTY.FuncRemove
 
-		for _, route := range routeList.Items {
+		for _, route := range selectedItems {
 			if isRemove { // remove
-				if utils.ContainsNamespacedName(suppliedItems, route.ObjectMeta) {
+				if len(suppliedItems) == 0 || utils.MatchNamespacedName(suppliedItems, route.ObjectMeta) {
 					deletionList = append(deletionList, route)
 				}
 			} else { // keep only
-				if utils.ContainsNamespacedName(suppliedItems, route.ObjectMeta) {
+				if !utils.MatchNamespacedName(suppliedItems, route.ObjectMeta) {
 					deletionList = append(deletionList, route)
 				}
 			}
@@ -86,8 +96,9 @@ func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) err
 }
 
 func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []osroutev1.Route) error {
-	if len(items) == 0 {
-		return nil
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
 	}
 	for _, route := range items {
 		err := routeAPI.Delete(k8sClient, &route)
@@ -0,0 +1,199 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	secretAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/secret"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	switch cfg.Function {
+	case openshiftTY.FuncAdd:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return nil, add(k8sClient, cfg)
+
+	case openshiftTY.FuncKeepOnly, openshiftTY.FuncRemove:
+		if len(cfg.Data) == 0 && !cfg.Config.Selector.IsDefined() {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		fallthrough
+	case openshiftTY.FuncRemoveAll:
+		return nil, performDelete(k8sClient, cfg)
+
+	case openshiftTY.FuncGet:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return get(k8sClient, cfg)
+
+	case openshiftTY.FuncMergeGlobalPullSecret:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		return nil, mergeGlobalPullSecret(k8sClient, cfg)
+
+	}
+
+	return nil, fmt.Errorf("unknown function. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+}
+
+func get(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	secrets := make([]corev1.Secret, 0)
+	for _, item := range utils.ToNamespacedNameSlice(cfg.Data) {
+		secret, err := secretAPI.Get(k8sClient, item.Name, item.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, *secret)
+	}
+	if len(secrets) == 1 {
+		return secrets[0], nil
+	}
+	return secrets, nil
+}
+
+// adds or updates the registries credentials on the cluster global pull secret
+func mergeGlobalPullSecret(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	registries := make([]openshiftTY.DockerRegistryAuth, 0)
+	for _, rawItem := range cfg.Data {
+		registry := openshiftTY.DockerRegistryAuth{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &registry)
+		if err != nil {
+			return err
+		}
+		registries = append(registries, registry)
+	}
+	err := secretAPI.MergeGlobalPullSecret(k8sClient, registries...)
+	if err != nil {
+		zap.L().Error("error on updating the global pull secret", zap.Error(err))
+		return err
+	}
+	for _, registry := range registries {
+		zap.L().Info("registry added into the global pull secret", zap.String("server", registry.Server), zap.String("username", registry.Username))
+	}
+	return nil
+}
+
+func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
+	}
+	secretList, err := secretAPI.List(k8sClient, opts)
+	if err != nil {
+		zap.L().Fatal("error on getting Secret list", zap.Error(err))
+	}
+
+	// filter by namespace
+	selectedItems := make([]corev1.Secret, 0)
+	for _, secret := range secretList.Items {
+		if cfg.Config.Selector.IsNamespaceSelected(secret.Namespace) {
+			selectedItems = append(selectedItems, secret)
+		}
+	}
+
+	if cfg.Function == openshiftTY.FuncRemoveAll {
+		return delete(k8sClient, cfg, selectedItems)
+	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
+		deletionList := make([]corev1.Secret, 0)
+
+		suppliedItems := utils.ToNamespacedNamePatterns(cfg.Data)
+
+		isRemove := cfg.Function == openshiftTY.FuncRemove
+
+		for _, secret := range selectedItems {
+			if isRemove { // remove
+				if len(suppliedItems) == 0 || utils.MatchNamespacedName(suppliedItems, secret.ObjectMeta) {
+					deletionList = append(deletionList, secret)
+				}
+			} else { // keep only
+				if !utils.MatchNamespacedName(suppliedItems, secret.ObjectMeta) {
+					deletionList = append(deletionList, secret)
+				}
+			}
+		}
+
+		return delete(k8sClient, cfg, deletionList)
+	}
+	return nil
+}
+
+func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []corev1.Secret) error {
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	for _, secret := range items {
+		err := secretAPI.Delete(k8sClient, &secret)
+		if err != nil {
+			return err
+		}
+		zap.L().Debug("deleted a Secret", zap.String("name", secret.Name), zap.String("namespace", secret.Namespace))
+	}
+	return nil
+}
+
+// data can be a Secret manifest or a config data with literals, files, directories, templates and docker registry
+func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
+	for _, cfgRaw := range cfg.Data {
+		secretCfg, ok := cfgRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var secret *corev1.Secret
+		if _, isManifest := secretCfg["metadata"]; isManifest {
+			secret = &corev1.Secret{}
+			err := formatterUtils.JsonMapToStruct(secretCfg, secret)
+			if err != nil {
+				return err
+			}
+		} else {
+			configData := openshiftTY.ConfigData{}
+			err := formatterUtils.YamlInterfaceToStruct(secretCfg, &configData)
+			if err != nil {
+				return err
+			}
+			secret, err = secretAPI.New(configData)
+			if err != nil {
+				zap.L().Error("error on building Secret data", zap.String("name", configData.Name), zap.String("namespace", configData.Namespace), zap.Error(err))
+				return err
+			}
+		}
+
+		existing, err := secretAPI.Get(k8sClient, secret.Name, secret.Namespace)
+		if utils.IgnoreNotFoundError(err) != nil {
+			return err
+		}
+		found := err == nil
+		if found {
+			zap.L().Debug("Secret exists", zap.String("name", secret.Name), zap.String("namespace", secret.Namespace))
+			if cfg.Config.Recreate {
+				zap.L().Debug("Secret recreate enabled", zap.String("name", secret.Name), zap.String("namespace", secret.Namespace))
+				err = secretAPI.Delete(k8sClient, existing)
+				if err != nil {
+					return err
+				}
+				found = false
+			}
+		}
+		if !found {
+			err = secretAPI.Create(k8sClient, secret)
+			if err != nil {
+				zap.L().Error("error on creating Secret", zap.String("name", secret.Name), zap.String("namespace", secret.Namespace), zap.Error(err))
+				return err
+			}
+			zap.L().Info("Secret created", zap.String("name", secret.Name), zap.String("namespace", secret.Namespace))
+		}
+	}
+	return nil
+}
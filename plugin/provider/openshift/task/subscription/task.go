@@ -1,12 +1,18 @@
 package task
 
 import (
+	"fmt"
+
 	"github.com/jkandasa/autoeasy/pkg/utils"
+	formatterUtils "github.com/jkandasa/autoeasy/pkg/utils/formatter"
+	csvAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/cluster_service_version"
 	operatorAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/operator"
 	subscriptionAPI "github.com/jkandasa/autoeasy/plugin/provider/openshift/api/subscription"
+	"github.com/jkandasa/autoeasy/plugin/provider/openshift/guard"
 	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
 	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -18,14 +24,85 @@ func Run(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{},
 	case openshiftTY.FuncKeepOnly, openshiftTY.FuncRemove, openshiftTY.FuncRemoveAll:
 		return nil, performDelete(k8sClient, cfg)
 
+	case openshiftTY.FuncGet:
+		return get(k8sClient, cfg)
+
+	case openshiftTY.FuncUpgrade:
+		if len(cfg.Data) == 0 {
+			return nil, fmt.Errorf("no data supplied. {kind:%s, function:%s}", cfg.Kind, cfg.Function)
+		}
+		cfg.Config.TimeoutConfig.UpdateDefaults()
+		return upgrade(k8sClient, cfg)
+
 	}
 
 	return nil, nil
 }
 
+// returns the installed operators, filtered by name and namespace of the supplied data
+// name matches the subscription or csv name, empty name or namespace matches all
+func get(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	filters := make([]types.NamespacedName, 0)
+	for _, rawItem := range cfg.Data {
+		filter := types.NamespacedName{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &filter)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	operators, err := csvAPI.Info(k8sClient, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 {
+		return operators, nil
+	}
+
+	filtered := make([]openshiftTY.Info, 0)
+	for _, operator := range operators {
+		for _, filter := range filters {
+			if filter.Namespace != "" && filter.Namespace != operator.Namespace {
+				continue
+			}
+			if filter.Name != "" && filter.Name != operator.Subscription && filter.Name != operator.Name {
+				continue
+			}
+			filtered = append(filtered, operator)
+			break
+		}
+	}
+	return filtered, nil
+}
+
+// walks the operators through the upgrade graph, returns the upgrade hops
+func upgrade(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) (interface{}, error) {
+	results := make([]openshiftTY.UpgradeResult, 0)
+	for _, rawItem := range cfg.Data {
+		request := openshiftTY.UpgradeRequest{}
+		err := formatterUtils.YamlInterfaceToStruct(rawItem, &request)
+		if err != nil {
+			return nil, err
+		}
+		result, err := operatorAPI.UpgradePath(k8sClient, request, cfg.Config.TimeoutConfig)
+		if err != nil {
+			zap.L().Error("error on upgrading an operator", zap.String("name", request.Name), zap.String("namespace", request.Namespace), zap.Any("result", result), zap.Error(err))
+			return result, err
+		}
+		results = append(results, *result)
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
 func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
-	opts := []client.ListOption{
-		client.InNamespace(""),
+	opts, err := cfg.Config.Selector.ListOptions()
+	if err != nil {
+		return err
 	}
 	subscriptionList, err := subscriptionAPI.List(k8sClient, opts)
 	if err != nil {
@@ -33,22 +110,30 @@ func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) err
 		return err
 	}
 
+	// filter by namespace
+	selectedItems := make([]corsosv1alpha1.Subscription, 0)
+	for _, subscription := range subscriptionList.Items {
+		if cfg.Config.Selector.IsNamespaceSelected(subscription.Namespace) {
+			selectedItems = append(selectedItems, subscription)
+		}
+	}
+
 	if cfg.Function == openshiftTY.FuncRemoveAll {
-		return delete(k8sClient, cfg, subscriptionList.Items)
+		return delete(k8sClient, cfg, selectedItems)
 	} else if cfg.Function == openshiftTY.FuncRemove || cfg.Function == openshiftTY.FuncKeepOnly {
 		deletionList := make([]corsosv1alpha1.Subscription, 0)
 
-		suppliedItems := utils.ToNamespacedNameSlice(cfg.Data)
+		suppliedItems := utils.ToNamespacedNamePatterns(cfg.Data)
 
 		isRemove := cfg.Function == openshiftTY.FuncRemove
 
-		for _, subscription := range subscriptionList.Items {
+		for _, subscription := range selectedItems {
 			if isRemove { // remove
-				if utils.ContainsNamespacedName(suppliedItems, subscription.ObjectMeta) {
+				if len(suppliedItems) == 0 || utils.MatchNamespacedName(suppliedItems, subscription.ObjectMeta) {
 					deletionList = append(deletionList, subscription)
 				}
 			} else { // keep only
-				if !utils.ContainsNamespacedName(suppliedItems, subscription.ObjectMeta) {
+				if !utils.MatchNamespacedName(suppliedItems, subscription.ObjectMeta) {
 					deletionList = append(deletionList, subscription)
 				}
 			}
@@ -61,11 +146,16 @@ func performDelete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) err
 }
 
 func delete(k8sClient client.Client, cfg *openshiftTY.ProviderConfig, items []corsosv1alpha1.Subscription) error {
-	if len(items) == 0 {
-		return nil
+	items, err := guard.Verify(cfg, items)
+	if err != nil || len(items) == 0 {
+		return err
 	}
 	for _, cs := range items {
-		err := subscriptionAPI.Delete(k8sClient, &cs)
+		if cfg.Config.Uninstall.IsDefined() {
+			err = operatorAPI.UninstallWithCleanup(k8sClient, &cs, cfg.Config.Uninstall, cfg.Config.TimeoutConfig)
+		} else {
+			err = subscriptionAPI.Delete(k8sClient, &cs)
+		}
 		if err != nil {
 			return err
 		}
@@ -105,7 +195,7 @@ func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
 				found = true
 				if cfg.Config.Recreate {
 					zap.L().Debug("Subscription recreate enabled", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace))
-					err = operatorAPI.UninstallWithMap(k8sClient, subscriptionCfg)
+					err = operatorAPI.UninstallWithMap(k8sClient, subscriptionCfg, cfg.Config.Uninstall, cfg.Config.TimeoutConfig)
 					if err != nil {
 						return err
 					}
@@ -116,7 +206,7 @@ func add(k8sClient client.Client, cfg *openshiftTY.ProviderConfig) error {
 			}
 		}
 		if !found {
-			err = operatorAPI.InstallWithMap(k8sClient, subscriptionCfg, cfg.Config.TimeoutConfig)
+			err = operatorAPI.InstallWithMap(k8sClient, subscriptionCfg, cfg.Config.OperatorGroup, cfg.Config.TimeoutConfig)
 			if err != nil {
 				zap.L().Fatal("error on adding a Subscription", zap.String("name", metadata.Name), zap.String("namespace", metadata.Namespace), zap.Error(err))
 				return err
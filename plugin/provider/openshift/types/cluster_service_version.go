@@ -5,10 +5,18 @@ import (
 	corsosv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 )
 
+// Info of an installed operator, subscription details are empty, when the csv is not subscribed
 type Info struct {
-	Name        string
-	DisplayName string
-	Version     version.OperatorVersion
-	Images      []string
-	Phase       corsosv1alpha1.ClusterServiceVersionPhase
+	Namespace    string                                    `json:"namespace"`
+	Name         string                                    `json:"name"`
+	DisplayName  string                                    `json:"displayName"`
+	Version      version.OperatorVersion                   `json:"version"`
+	Images       []string                                  `json:"images"`
+	Phase        corsosv1alpha1.ClusterServiceVersionPhase `json:"phase"`
+	Subscription string                                    `json:"subscription"`
+	Channel      string                                    `json:"channel"`
+	Source       string                                    `json:"source"`
+	Approval     corsosv1alpha1.Approval                   `json:"approval"`
+	InstalledCSV string                                    `json:"installedCSV"`
+	CurrentCSV   string                                    `json:"currentCSV"`
 }
@@ -0,0 +1,33 @@
+package types
+
+// ConfigData builds a Secret or a ConfigMap
+// data is collected from literals, files ("key=path" or "path"), directories and templates.
+// Secret values are base64 encoded automatically
+type ConfigData struct {
+	Name           string              `yaml:"name"`
+	Namespace      string              `yaml:"namespace"`
+	Type           string              `yaml:"type"`
+	Labels         map[string]string   `yaml:"labels"`
+	Annotations    map[string]string   `yaml:"annotations"`
+	Literals       map[string]string   `yaml:"literals"`
+	Files          []string            `yaml:"files"`
+	Directories    []string            `yaml:"directories"`
+	Templates      []TemplateSource    `yaml:"templates"`
+	DockerRegistry *DockerRegistryAuth `yaml:"docker_registry"`
+}
+
+// TemplateSource renders a template from a file or from the inline template string
+type TemplateSource struct {
+	Key       string                 `yaml:"key"`
+	File      string                 `yaml:"file"`
+	Template  string                 `yaml:"template"`
+	Variables map[string]interface{} `yaml:"variables"`
+}
+
+// DockerRegistryAuth credential of a registry
+type DockerRegistryAuth struct {
+	Server   string `yaml:"server"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Email    string `yaml:"email"`
+}
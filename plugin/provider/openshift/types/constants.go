@@ -12,14 +12,49 @@ const (
 	FuncWaitForDelete = "wait_for_delete"
 	FuncLogin         = "login"
 	FuncLogout        = "logout"
+	FuncApply         = "apply"
+	FuncPatch         = "patch"
+
+	FuncWaitForCondition  = "wait_for_condition"
+	FuncExec              = "exec"
+	FuncLogs              = "logs"
+	FuncClose             = "close"
+	FuncCopyToPod         = "copy_to_pod"
+	FuncCopyFromPod       = "copy_from_pod"
+	FuncWaitForCompletion = "wait_for_completion"
+	FuncTrigger           = "trigger"
+	FuncUpgrade           = "upgrade"
+	FuncConvertToIDMS     = "convert_to_idms"
+	FuncWaitForMCP        = "wait_for_mcp"
+
+	FuncMergeGlobalPullSecret = "merge_global_pull_secret"
 
 	// kinds
 	KindSubscription             = "Subscription"
 	KindImageContentSourcePolicy = "ImageContentSourcePolicy"
+	KindImageDigestMirrorSet     = "ImageDigestMirrorSet"
+	KindImageTagMirrorSet        = "ImageTagMirrorSet"
 	KindCatalogSource            = "CatalogSource"
 	KindNamespace                = "Namespace"
 	KindDeployment               = "Deployment"
 	KindPod                      = "Pod"
 	KindRoute                    = "Route"
+	KindResource                 = "Resource"
+	KindPortForward              = "PortForward"
+	KindJob                      = "Job"
+	KindCronJob                  = "CronJob"
+	KindSecret                   = "Secret"
+	KindConfigMap                = "ConfigMap"
+	KindMachineConfigPool        = "MachineConfigPool"
 	KindInternal                 = "Internal"
+
+	// patch types
+	PatchTypeMerge     = "merge"
+	PatchTypeJSON      = "json"
+	PatchTypeStrategic = "strategic"
+
+	DefaultFieldManager = "autoeasy"
+
+	// cluster name of the provider config
+	DefaultCluster = ""
 )
@@ -0,0 +1,9 @@
+package types
+
+// ImageMirrors of ImageDigestMirrorSet and ImageTagMirrorSet
+// the mirror sets are not available in the vendored openshift api, handled as unstructured
+type ImageMirrors struct {
+	Source             string   `json:"source" yaml:"source"`
+	Mirrors            []string `json:"mirrors,omitempty" yaml:"mirrors"`
+	MirrorSourcePolicy string   `json:"mirrorSourcePolicy,omitempty" yaml:"mirror_source_policy"`
+}
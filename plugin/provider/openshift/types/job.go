@@ -0,0 +1,22 @@
+package types
+
+// JobStatus of a Job
+type JobStatus struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Active    int32  `json:"active"`
+	Succeeded int32  `json:"succeeded"`
+	Failed    int32  `json:"failed"`
+	Completed bool   `json:"completed"`
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// TriggerRequest creates a Job from the CronJob
+// job name is generated from the CronJob name, if not supplied
+type TriggerRequest struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+	JobName   string `yaml:"job_name"`
+	Wait      bool   `yaml:"wait"`
+}
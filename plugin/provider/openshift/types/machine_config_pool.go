@@ -0,0 +1,36 @@
+package types
+
+import "fmt"
+
+// MachineConfigPoolStatus of a pool rollout
+// the machine config pool is not available in the vendored openshift api, handled as unstructured
+type MachineConfigPoolStatus struct {
+	Name                    string `json:"name"`
+	Configuration           string `json:"configuration"`
+	TargetConfiguration     string `json:"targetConfiguration"`
+	Updated                 bool   `json:"updated"`
+	Updating                bool   `json:"updating"`
+	Degraded                bool   `json:"degraded"`
+	MachineCount            int64  `json:"machineCount"`
+	UpdatedMachineCount     int64  `json:"updatedMachineCount"`
+	ReadyMachineCount       int64  `json:"readyMachineCount"`
+	UnavailableMachineCount int64  `json:"unavailableMachineCount"`
+	DegradedMachineCount    int64  `json:"degradedMachineCount"`
+	ObservedGeneration      int64  `json:"observedGeneration"`
+	Generation              int64  `json:"generation"`
+}
+
+// IsRolledOut returns true, when all the machines of the pool are updated to the target configuration
+func (s *MachineConfigPoolStatus) IsRolledOut() bool {
+	return s.ObservedGeneration == s.Generation &&
+		s.Configuration == s.TargetConfiguration &&
+		s.Updated && !s.Updating && !s.Degraded &&
+		s.UpdatedMachineCount == s.MachineCount &&
+		s.ReadyMachineCount == s.MachineCount &&
+		s.UnavailableMachineCount == 0
+}
+
+// Progress of the pool rollout
+func (s *MachineConfigPoolStatus) Progress() string {
+	return fmt.Sprintf("updated:%d/%d, ready:%d, unavailable:%d, degraded:%d", s.UpdatedMachineCount, s.MachineCount, s.ReadyMachineCount, s.UnavailableMachineCount, s.DegradedMachineCount)
+}
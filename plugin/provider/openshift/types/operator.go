@@ -0,0 +1,56 @@
+package types
+
+import "time"
+
+// UpgradeRequest walks the operator through the upgrade graph of the channel
+// stops on the target CSV, on the max hops or on the latest CSV of the channel
+type UpgradeRequest struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+	Channel   string `yaml:"channel"`
+	TargetCSV string `yaml:"target_csv"`
+	MaxHops   int    `yaml:"max_hops"`
+}
+
+// UpgradeHop of the upgrade path
+type UpgradeHop struct {
+	From        string        `json:"from"`
+	To          string        `json:"to"`
+	InstallPlan string        `json:"installPlan"`
+	TimeTaken   time.Duration `json:"timeTaken"`
+}
+
+// UpgradeResult of the upgrade path
+type UpgradeResult struct {
+	Name         string        `json:"name"`
+	Namespace    string        `json:"namespace"`
+	Channel      string        `json:"channel"`
+	InstalledCSV string        `json:"installedCSV"`
+	Hops         []UpgradeHop  `json:"hops"`
+	TimeTaken    time.Duration `json:"timeTaken"`
+}
+
+// UninstallConfig cleanups the resources of the operator on uninstall
+// operands are removed before the operator, the operator processes the finalizers of the operands
+type UninstallConfig struct {
+	FullCleanup         bool `yaml:"full_cleanup"`
+	RemoveOperands      bool `yaml:"remove_operands"`
+	RemoveCRDs          bool `yaml:"remove_crds"`
+	RemoveOperatorGroup bool `yaml:"remove_operator_group"`
+	RemoveInstallPlans  bool `yaml:"remove_install_plans"`
+}
+
+// Update enables all the cleanup options on full cleanup
+func (uc *UninstallConfig) Update() {
+	if uc.FullCleanup {
+		uc.RemoveOperands = true
+		uc.RemoveCRDs = true
+		uc.RemoveOperatorGroup = true
+		uc.RemoveInstallPlans = true
+	}
+}
+
+// IsDefined returns true, if any of the cleanup option enabled
+func (uc *UninstallConfig) IsDefined() bool {
+	return uc.FullCleanup || uc.RemoveOperands || uc.RemoveCRDs || uc.RemoveOperatorGroup || uc.RemoveInstallPlans
+}
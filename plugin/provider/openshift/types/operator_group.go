@@ -0,0 +1,11 @@
+package types
+
+// OperatorGroupConfig of the operator install
+// install mode decided from the package supported install modes, if not supplied.
+// AllNamespaces preferred on "openshift-operators", OwnNamespace preferred on the other namespaces.
+// target namespaces are required on SingleNamespace and MultiNamespace install modes
+type OperatorGroupConfig struct {
+	Skip             bool     `yaml:"skip"`
+	InstallMode      string   `yaml:"install_mode"`
+	TargetNamespaces []string `yaml:"target_namespaces"`
+}
@@ -0,0 +1,74 @@
+package types
+
+import "time"
+
+// PodSelector selects pods with name, deployment or label selector
+type PodSelector struct {
+	Name          string `yaml:"name" json:"name"`
+	Namespace     string `yaml:"namespace" json:"namespace"`
+	Deployment    string `yaml:"deployment" json:"deployment"`
+	LabelSelector string `yaml:"label_selector" json:"labelSelector"`
+}
+
+// ExecRequest runs a command in a container
+// on deployment or label selector, the command executed on the first running pod
+type ExecRequest struct {
+	PodSelector    `yaml:",inline"`
+	Container      string   `yaml:"container"`
+	Command        []string `yaml:"command"`
+	Stdin          string   `yaml:"stdin"`
+	IgnoreExitCode bool     `yaml:"ignore_exit_code"`
+}
+
+// ExecResult of a command
+type ExecResult struct {
+	Pod       string `json:"pod"`
+	Namespace string `json:"namespace"`
+	Container string `json:"container"`
+	Stdout    string `json:"stdout"`
+	Stderr    string `json:"stderr"`
+	ExitCode  int    `json:"exitCode"`
+}
+
+// LogsRequest fetches logs of the selected pods
+// follow keeps reading the logs till the task timeout
+// file: logs are written to the file, instead of the result
+type LogsRequest struct {
+	PodSelector   `yaml:",inline"`
+	Container     string        `yaml:"container"`
+	AllContainers bool          `yaml:"all_containers"`
+	Since         time.Duration `yaml:"since"`
+	Tail          int64         `yaml:"tail"`
+	Follow        bool          `yaml:"follow"`
+	Previous      bool          `yaml:"previous"`
+	Timestamps    bool          `yaml:"timestamps"`
+	File          string        `yaml:"file"`
+}
+
+// LogsResult of a container
+type LogsResult struct {
+	Pod       string `json:"pod"`
+	Namespace string `json:"namespace"`
+	Container string `json:"container"`
+	Logs      string `json:"logs,omitempty"`
+	File      string `json:"file,omitempty"`
+}
+
+// CopyRequest copies files between the local path and the container
+// on deployment or label selector, the files are copied to/from the first running pod
+type CopyRequest struct {
+	PodSelector `yaml:",inline"`
+	Container   string `yaml:"container"`
+	Source      string `yaml:"src"`
+	Destination string `yaml:"dest"`
+}
+
+// CopyResult of a copy request
+type CopyResult struct {
+	Pod         string `json:"pod"`
+	Namespace   string `json:"namespace"`
+	Container   string `json:"container"`
+	Source      string `json:"src"`
+	Destination string `json:"dest"`
+	Files       int    `json:"files"`
+}
@@ -4,11 +4,39 @@ import (
 	iostreamTY "github.com/jkandasa/autoeasy/pkg/types/iostream"
 )
 
+// PortForwardRequest forwards the local ports to a pod, deployment or service
+// ports format: "localPort:remotePort", local port ":remotePort" or "0:remotePort" selects a random port
 type PortForwardRequest struct {
 	Namespace  string                `json:"namespace"`
 	Pod        string                `json:"pod"`
 	Deployment string                `json:"deployment"`
+	Service    string                `json:"service"`
 	Addresses  []string              `json:"addresses"`
 	Ports      []string              `json:"ports"`
-	Streams    *iostreamTY.IOStreams `json:"-"`
+	Streams    *iostreamTY.IOStreams `json:"-" yaml:"-"`
+}
+
+// PortForwardData of the port forward task
+// the forward is closed after the "close_after" number of tasks or with the close function.
+// tasks of all the providers are counted, the task opened the forward is not counted
+// zero "close_after" keeps the forward till the close function or till the end of the execution
+type PortForwardData struct {
+	PortForwardRequest `yaml:",inline"`
+	Name               string `yaml:"name"`
+	CloseAfter         int    `yaml:"close_after"`
+}
+
+// ForwardedPort of an active port forward
+type ForwardedPort struct {
+	Local  uint16 `json:"local"`
+	Remote uint16 `json:"remote"`
+}
+
+// PortForwardResult of an active port forward, stored in the data repository
+type PortForwardResult struct {
+	Name      string          `json:"name"`
+	Namespace string          `json:"namespace"`
+	Pod       string          `json:"pod"`
+	Address   string          `json:"address"`
+	Ports     []ForwardedPort `json:"ports"`
 }
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"github.com/jkandasa/autoeasy/pkg/utils"
+)
+
+// DefaultProtectedNamespaces are protected from the delete functions, unless allowed explicitly
+var DefaultProtectedNamespaces = []string{"default", "kube-*", "openshift", "openshift-*"}
+
+// ProtectionConfig protects the resources from the delete functions
+// namespaces can be names, globs or regular expressions (wrapped with "/")
+// allowed_namespaces takes priority over the protected namespaces
+type ProtectionConfig struct {
+	Disabled            bool     `yaml:"disabled"`
+	ProtectedNamespaces []string `yaml:"protected_namespaces"`
+	AllowedNamespaces   []string `yaml:"allowed_namespaces"`
+}
+
+// Merge returns a new config with the lists of both configs
+// other is the lower level config (cluster or task), it can not disable the protection
+// when this config protects namespaces explicitly
+func (pc ProtectionConfig) Merge(other ProtectionConfig) ProtectionConfig {
+	return ProtectionConfig{
+		Disabled:            pc.Disabled || (other.Disabled && len(pc.ProtectedNamespaces) == 0),
+		ProtectedNamespaces: append(append([]string{}, pc.ProtectedNamespaces...), other.ProtectedNamespaces...),
+		AllowedNamespaces:   append(append([]string{}, pc.AllowedNamespaces...), other.AllowedNamespaces...),
+	}
+}
+
+// IsProtectedNamespace returns the protected status of the namespace
+func (pc *ProtectionConfig) IsProtectedNamespace(namespace string) bool {
+	if pc.Disabled || namespace == "" {
+		return false
+	}
+	if utils.MatchString(pc.AllowedNamespaces, namespace) {
+		return false
+	}
+	return utils.MatchString(DefaultProtectedNamespaces, namespace) || utils.MatchString(pc.ProtectedNamespaces, namespace)
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestProtectionMerge(t *testing.T) {
+	tests := []struct {
+		name      string
+		top       ProtectionConfig
+		lower     ProtectionConfig
+		namespace string
+		expected  bool
+	}{
+		{name: "default namespace", namespace: "openshift-config", expected: true},
+		{name: "lower level disables", lower: ProtectionConfig{Disabled: true}, namespace: "openshift-config", expected: false},
+		{name: "top level disables", top: ProtectionConfig{Disabled: true}, namespace: "openshift-config", expected: false},
+		{name: "lower level can not disable the top level policy", top: ProtectionConfig{ProtectedNamespaces: []string{"prod-*"}}, lower: ProtectionConfig{Disabled: true}, namespace: "prod-db", expected: true},
+		{name: "lower level adds namespaces", lower: ProtectionConfig{ProtectedNamespaces: []string{"prod-*"}}, namespace: "prod-db", expected: true},
+	}
+
+	for _, test := range tests {
+		merged := test.top.Merge(test.lower)
+		received := merged.IsProtectedNamespace(test.namespace)
+		if received != test.expected {
+			t.Errorf("%s: expected:%v, received:%v", test.name, test.expected, received)
+		}
+	}
+}
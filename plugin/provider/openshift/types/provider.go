@@ -7,26 +7,37 @@ import (
 
 // PluginConfig struct
 type PluginConfig struct {
-	LoadClient     bool          `yaml:"load_client"`
-	LoadFromConfig bool          `yaml:"load_from_config"`
-	ConfigFile     string        `yaml:"config_file"`
-	Server         string        `yaml:"server"`
-	Username       string        `yaml:"username"`
-	Password       string        `yaml:"password"`
-	Token          string        `yaml:"token"`
-	Insecure       bool          `yaml:"insecure"`
-	TimeoutConfig  TimeoutConfig `yaml:"timeout_config"`
+	LoadClient     bool             `yaml:"load_client"`
+	LoadFromConfig bool             `yaml:"load_from_config"`
+	ConfigFile     string           `yaml:"config_file"`
+	Server         string           `yaml:"server"`
+	Username       string           `yaml:"username"`
+	Password       string           `yaml:"password"`
+	Token          string           `yaml:"token"`
+	Context        string           `yaml:"context"`
+	KubeCluster    string           `yaml:"kubeconfig_cluster"`
+	CAData         string           `yaml:"ca_data"`
+	CAFile         string           `yaml:"ca_file"`
+	ProxyURL       string           `yaml:"proxy_url"`
+	Insecure       bool             `yaml:"insecure"`
+	AutoConfirm    bool             `yaml:"auto_confirm"`
+	Protection     ProtectionConfig `yaml:"protection"`
+	TimeoutConfig  TimeoutConfig    `yaml:"timeout_config"`
+	// additional named clusters, selected with "cluster" on the task
+	Clusters map[string]PluginConfig `yaml:"clusters"`
 }
 
 func (p *PluginConfig) Validate() error {
 	if p.Server == "" {
 		return errors.New("server url can not be empty")
 	}
+	if p.Token != "" {
+		return nil
+	}
 	if p.Username == "" {
 		return errors.New("username can not be empty")
 	}
-
-	if p.Token == "" && p.Password == "" {
+	if p.Password == "" {
 		return errors.New("either token or password can not be empty")
 	}
 	return nil
@@ -34,6 +45,7 @@ func (p *PluginConfig) Validate() error {
 
 // ProviderConfig struct
 type ProviderConfig struct {
+	Cluster  string        `yaml:"cluster"`
 	Kind     string        `yaml:"kind"`
 	Function string        `yaml:"function"`
 	Config   TaskConfig    `yaml:"config"`
@@ -41,8 +53,17 @@ type ProviderConfig struct {
 }
 
 type TaskConfig struct {
-	Recreate      bool          `yaml:"recreate"`
-	TimeoutConfig TimeoutConfig `yaml:"timeout_config"`
+	Recreate       bool                `yaml:"recreate"`
+	FieldManager   string              `yaml:"field_manager"`
+	ForceConflicts bool                `yaml:"force_conflicts"`
+	Selector       Selector            `yaml:"selector"`
+	Confirm        bool                `yaml:"confirm"`
+	Preview        bool                `yaml:"preview"`
+	Protection     ProtectionConfig    `yaml:"protection"`
+	OperatorGroup  OperatorGroupConfig `yaml:"operator_group"`
+	Uninstall      UninstallConfig     `yaml:"uninstall"`
+	NoWait         bool                `yaml:"no_wait"` // skips the completion wait on adding a Job
+	TimeoutConfig  TimeoutConfig       `yaml:"timeout_config"`
 }
 
 type TimeoutConfig struct {
@@ -63,3 +84,31 @@ func (tc *TimeoutConfig) UpdateDefaults() {
 		tc.ExpectedSuccessCount = 3
 	}
 }
+
+// UpdateRolloutDefaults updates the defaults of the machine config pool rollout
+// the nodes are drained and rebooted one by one, takes longer than the other waits
+func (tc *TimeoutConfig) UpdateRolloutDefaults() {
+	if tc.Timeout == 0 {
+		tc.Timeout = time.Minute * 30
+	}
+	if tc.ScanInterval == 0 {
+		tc.ScanInterval = time.Second * 20
+	}
+	if tc.ExpectedSuccessCount == 0 {
+		tc.ExpectedSuccessCount = 3
+	}
+}
+
+// UpdateJobDefaults updates the defaults of the Job and CronJob tasks
+// jobs are used for data migrations and tests, runs longer than the other waits
+func (tc *TimeoutConfig) UpdateJobDefaults() {
+	if tc.Timeout == 0 {
+		tc.Timeout = time.Minute * 30
+	}
+	if tc.ScanInterval == 0 {
+		tc.ScanInterval = time.Second * 10
+	}
+	if tc.ExpectedSuccessCount == 0 {
+		tc.ExpectedSuccessCount = 3
+	}
+}
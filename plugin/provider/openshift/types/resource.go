@@ -0,0 +1,54 @@
+package types
+
+// ResourceRef refers a resource of any kind
+type ResourceRef struct {
+	APIVersion string `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string `yaml:"kind" json:"kind"`
+	Name       string `yaml:"name" json:"name"`
+	Namespace  string `yaml:"namespace" json:"namespace"`
+}
+
+// PatchData holds a patch of a resource
+// type: merge, json or strategic
+// patch: patch content, as a yaml/json object or as a raw string
+type PatchData struct {
+	ResourceRef `yaml:",inline"`
+	Type        string      `yaml:"type"`
+	Patch       interface{} `yaml:"patch"`
+}
+
+// ConditionData holds a resource and the condition to wait for
+// all the supplied checks (condition, jsonpath and compare) should be satisfied
+// compare is a single comparison, not an expression language, format: "<path> <operator> <value>"
+//   - path: gjson path on the resource, example: "status.readyReplicas", "status.conditions.#(type==\"Ready\").status"
+//   - operator: "==", "!=", ">=", "<=", ">", "<". operators inside the quotes, brackets and parentheses of the path are ignored
+//   - value: plain or quoted with single or double quotes
+//
+// values are compared as numbers, if both sides are numbers. otherwise only "==" and "!=" supported
+// the check is not met, if the path is not available on the resource
+type ConditionData struct {
+	ResourceRef `yaml:",inline"`
+	Condition   *StatusCondition `yaml:"condition"`
+	JSONPath    string           `yaml:"jsonpath"`
+	Value       string           `yaml:"value"`
+	Compare     string           `yaml:"compare"`
+}
+
+// StatusCondition checks an entry on the ".status.conditions"
+type StatusCondition struct {
+	Type   string `yaml:"type"`
+	Status string `yaml:"status"`
+	Reason string `yaml:"reason"`
+}
+
+func (rr *ResourceRef) GetName() string {
+	return rr.Name
+}
+
+func (rr *ResourceRef) GetNamespace() string {
+	return rr.Namespace
+}
+
+func (rr *ResourceRef) GetKind() string {
+	return rr.Kind
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"github.com/jkandasa/autoeasy/pkg/utils"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Selector filters the resources on remove, keep_only and remove_all functions
+// namespaces: names, globs or regular expressions (wrapped with "/")
+// labels: label selector, example: "test-run=123,app!=db"
+// fields: field selector, example: "status.phase=Failed"
+type Selector struct {
+	Namespaces []string `yaml:"namespaces"`
+	Labels     string   `yaml:"labels"`
+	Fields     string   `yaml:"fields"`
+}
+
+// IsDefined returns true, if any of the filters defined
+func (s *Selector) IsDefined() bool {
+	return len(s.Namespaces) > 0 || s.Labels != "" || s.Fields != ""
+}
+
+// ListOptions returns the list options of all the namespaces with label and field selectors
+func (s *Selector) ListOptions() ([]client.ListOption, error) {
+	opts := []client.ListOption{
+		client.InNamespace(""),
+	}
+	if s.Labels != "" {
+		labelSelector, err := labels.Parse(s.Labels)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.MatchingLabelsSelector{Selector: labelSelector})
+	}
+	if s.Fields != "" {
+		fieldSelector, err := fields.ParseSelector(s.Fields)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.MatchingFieldsSelector{Selector: fieldSelector})
+	}
+	return opts, nil
+}
+
+// IsNamespaceSelected returns true, if the namespace selected or no namespaces defined
+func (s *Selector) IsNamespaceSelected(namespace string) bool {
+	if len(s.Namespaces) == 0 {
+		return true
+	}
+	return utils.MatchString(s.Namespaces, namespace)
+}
@@ -0,0 +1,248 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	funcUtils "github.com/jkandasa/autoeasy/pkg/utils/function"
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sWatch "k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsReadyFunc verifies the state of the watched objects
+// objects map holds the latest state of the targets, deleted or not available targets will be missing
+type IsReadyFunc func(objects map[types.NamespacedName]client.Object) (bool, error)
+
+type event struct {
+	target types.NamespacedName
+	object client.Object
+	err    error
+}
+
+// WaitFor watches the targets and waits till the isReady returns true.
+// each target is watched individually with a "metadata.name" field selector.
+// isReady is verified on each event and on each scan interval,
+// success count is updated on the first success from an event and on each scan interval.
+// falls back to polling, if the client does not support watch
+func WaitFor(k8sClient client.Client, newList func() client.ObjectList, targets []types.NamespacedName, isReady IsReadyFunc, tc openshiftTY.TimeoutConfig) error {
+	watchClient, ok := k8sClient.(client.WithWatch)
+	if !ok {
+		zap.L().Debug("client does not support watch, falls back to polling")
+		return poll(k8sClient, newList, targets, isReady, tc)
+	}
+
+	expectedSuccessCount := tc.ExpectedSuccessCount
+	if expectedSuccessCount < 1 {
+		expectedSuccessCount = 1
+	}
+
+	startTime := time.Now()
+	zap.L().Debug("watch started", zap.Any("targets", targets), zap.String("timeout", tc.Timeout.String()), zap.String("scanInterval", tc.ScanInterval.String()), zap.Int("expectedSuccessCount", expectedSuccessCount))
+	defer func() {
+		zap.L().Debug("watch completed", zap.Any("targets", targets), zap.String("timeTaken", time.Since(startTime).String()))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), tc.Timeout)
+	defer cancel()
+
+	// load the current state and start watches
+	objects := map[types.NamespacedName]client.Object{}
+	events := make(chan event)
+	for _, target := range targets {
+		object, resourceVersion, err := getTarget(ctx, k8sClient, newList, target)
+		if err != nil {
+			return err
+		}
+		if object != nil {
+			objects[target] = object
+		}
+		go watchTarget(ctx, watchClient, newList, target, resourceVersion, events)
+	}
+
+	ticker := time.NewTicker(tc.ScanInterval)
+	defer ticker.Stop()
+
+	successCount := 0
+	verify := func(fromEvent bool) (bool, error) {
+		ready, err := isReady(objects)
+		if err != nil {
+			return false, err
+		}
+		if !ready {
+			successCount = 0
+			return false, nil
+		}
+		// events can come in burst, already counted success will be confirmed on the scan interval
+		if fromEvent && successCount > 0 {
+			return false, nil
+		}
+		successCount++
+		return successCount >= expectedSuccessCount, nil
+	}
+
+	// verify the initial state
+	done, err := verify(true)
+	if err != nil || done {
+		return err
+	}
+
+	for {
+		select {
+		case ev := <-events:
+			if ev.err != nil {
+				return ev.err
+			}
+			if ev.object == nil {
+				delete(objects, ev.target)
+			} else {
+				objects[ev.target] = ev.object
+			}
+			done, err := verify(true)
+			if err != nil || done {
+				return err
+			}
+
+		case <-ticker.C:
+			done, err := verify(false)
+			if err != nil || done {
+				return err
+			}
+
+		case <-ctx.Done():
+			return fmt.Errorf("reached timeout: %s", tc.Timeout.String())
+		}
+	}
+}
+
+// watches a target and sends the changes to the events channel
+// watch will be restarted, if the server closes it
+func watchTarget(ctx context.Context, watchClient client.WithWatch, newList func() client.ObjectList, target types.NamespacedName, resourceVersion string, events chan<- event) {
+	send := func(ev event) bool {
+		select {
+		case events <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		opts := getListOptions(target)
+		opts.Raw = &metav1.ListOptions{ResourceVersion: resourceVersion}
+		watcher, err := watchClient.Watch(ctx, newList(), opts)
+		if err != nil {
+			if ctx.Err() == nil {
+				send(event{target: target, err: err})
+			}
+			return
+		}
+
+		expired := false
+		for result := range watcher.ResultChan() {
+			switch result.Type {
+			case k8sWatch.Added, k8sWatch.Modified:
+				object, ok := result.Object.(client.Object)
+				if !ok || !isTarget(object, target) {
+					continue
+				}
+				resourceVersion = object.GetResourceVersion()
+				if !send(event{target: target, object: object}) {
+					watcher.Stop()
+					return
+				}
+
+			case k8sWatch.Deleted:
+				object, ok := result.Object.(client.Object)
+				if !ok || !isTarget(object, target) {
+					continue
+				}
+				resourceVersion = object.GetResourceVersion()
+				if !send(event{target: target}) {
+					watcher.Stop()
+					return
+				}
+
+			case k8sWatch.Error:
+				// resource version might be expired, reload the target
+				zap.L().Debug("received error on watch", zap.Any("target", target), zap.Any("object", result.Object))
+				expired = true
+			}
+			if expired {
+				break
+			}
+		}
+		watcher.Stop()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// reload the target, the changes missed between the watches will be sent as an event
+		if expired {
+			object, newResourceVersion, err := getTarget(ctx, watchClient, newList, target)
+			if err != nil {
+				send(event{target: target, err: err})
+				return
+			}
+			resourceVersion = newResourceVersion
+			if !send(event{target: target, object: object}) {
+				return
+			}
+		}
+	}
+}
+
+// returns the target object and the resource version of the list
+func getTarget(ctx context.Context, k8sClient client.Client, newList func() client.ObjectList, target types.NamespacedName) (client.Object, string, error) {
+	list := newList()
+	err := k8sClient.List(ctx, list, getListOptions(target))
+	if err != nil {
+		return nil, "", err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, item := range items {
+		object, ok := item.(client.Object)
+		if ok && isTarget(object, target) {
+			return object, list.GetResourceVersion(), nil
+		}
+	}
+	return nil, list.GetResourceVersion(), nil
+}
+
+func isTarget(object client.Object, target types.NamespacedName) bool {
+	return object.GetName() == target.Name && (target.Namespace == "" || object.GetNamespace() == target.Namespace)
+}
+
+func getListOptions(target types.NamespacedName) *client.ListOptions {
+	opts := &client.ListOptions{}
+	client.InNamespace(target.Namespace).ApplyToList(opts)
+	client.MatchingFields{"metadata.name": target.Name}.ApplyToList(opts)
+	return opts
+}
+
+// polls the targets on each scan interval
+func poll(k8sClient client.Client, newList func() client.ObjectList, targets []types.NamespacedName, isReady IsReadyFunc, tc openshiftTY.TimeoutConfig) error {
+	executeFunc := func() (bool, error) {
+		objects := map[types.NamespacedName]client.Object{}
+		for _, target := range targets {
+			object, _, err := getTarget(context.Background(), k8sClient, newList, target)
+			if err != nil {
+				return false, err
+			}
+			if object != nil {
+				objects[target] = object
+			}
+		}
+		return isReady(objects)
+	}
+	return funcUtils.ExecuteWithTimeoutAndContinuesSuccessCount(executeFunc, tc.Timeout, tc.ScanInterval, tc.ExpectedSuccessCount)
+}
@@ -0,0 +1,204 @@
+package watch
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	openshiftTY "github.com/jkandasa/autoeasy/plugin/provider/openshift/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sWatch "k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testTarget = types.NamespacedName{Name: "my-config", Namespace: "default"}
+	testTC     = openshiftTY.TimeoutConfig{Timeout: time.Second * 5, ScanInterval: time.Millisecond * 20, ExpectedSuccessCount: 1}
+)
+
+// fakeWatchClient serves the watches from the fake watchers, the test controls the events
+// other calls are served by the controller-runtime fake client
+type fakeWatchClient struct {
+	client.WithWatch
+	mutex    sync.Mutex
+	watchers []*k8sWatch.FakeWatcher
+	started  chan *k8sWatch.FakeWatcher
+}
+
+func newFakeWatchClient(t *testing.T, objects ...client.Object) *fakeWatchClient {
+	fc := &fakeWatchClient{
+		WithWatch: fake.NewClientBuilder().WithObjects(objects...).Build(),
+		started:   make(chan *k8sWatch.FakeWatcher, 10),
+	}
+	t.Cleanup(func() {
+		fc.mutex.Lock()
+		defer fc.mutex.Unlock()
+		for _, watcher := range fc.watchers {
+			watcher.Stop()
+		}
+	})
+	return fc
+}
+
+func (fc *fakeWatchClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (k8sWatch.Interface, error) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	watcher := k8sWatch.NewFake()
+	fc.watchers = append(fc.watchers, watcher)
+	fc.started <- watcher
+	return watcher, nil
+}
+
+// waits for the next watch request
+func (fc *fakeWatchClient) nextWatcher(t *testing.T) *k8sWatch.FakeWatcher {
+	select {
+	case watcher := <-fc.started:
+		return watcher
+	case <-time.After(time.Second * 2):
+		t.Fatal("watch not started")
+	}
+	return nil
+}
+
+// pollingClient hides the watch support of the fake client
+type pollingClient struct {
+	client.Client
+}
+
+func newConfigMap(ready string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: testTarget.Name, Namespace: testTarget.Namespace},
+		Data:       map[string]string{"ready": ready},
+	}
+}
+
+func newList() client.ObjectList {
+	return &corev1.ConfigMapList{}
+}
+
+func isReady(objects map[types.NamespacedName]client.Object) (bool, error) {
+	object, found := objects[testTarget]
+	if !found {
+		return false, nil
+	}
+	configMap, ok := object.(*corev1.ConfigMap)
+	return ok && configMap.Data["ready"] == "true", nil
+}
+
+func isDeleted(objects map[types.NamespacedName]client.Object) (bool, error) {
+	return len(objects) == 0, nil
+}
+
+// runs the WaitFor in background and returns the result channel
+func waitFor(k8sClient client.Client, isReady IsReadyFunc, tc openshiftTY.TimeoutConfig) chan error {
+	result := make(chan error, 1)
+	go func() {
+		result <- WaitFor(k8sClient, newList, []types.NamespacedName{testTarget}, isReady, tc)
+	}()
+	return result
+}
+
+func expectResult(t *testing.T, result chan error, expectError bool) {
+	select {
+	case err := <-result:
+		if expectError && err == nil {
+			t.Errorf("expected error")
+		} else if !expectError && err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second * 10):
+		t.Fatal("wait not completed")
+	}
+}
+
+func TestWaitForModifiedEvent(t *testing.T) {
+	fc := newFakeWatchClient(t, newConfigMap("false"))
+	result := waitFor(fc, isReady, testTC)
+
+	watcher := fc.nextWatcher(t)
+	// events of the other objects are ignored
+	other := newConfigMap("true")
+	other.Name = "other-config"
+	watcher.Modify(other)
+	watcher.Modify(newConfigMap("true"))
+
+	expectResult(t, result, false)
+}
+
+func TestWaitForDeletedEvent(t *testing.T) {
+	fc := newFakeWatchClient(t, newConfigMap("false"))
+	result := waitFor(fc, isDeleted, testTC)
+
+	watcher := fc.nextWatcher(t)
+	watcher.Delete(newConfigMap("false"))
+
+	expectResult(t, result, false)
+}
+
+func TestWaitForErrorEventReload(t *testing.T) {
+	fc := newFakeWatchClient(t, newConfigMap("false"))
+	result := waitFor(fc, isReady, testTC)
+
+	watcher := fc.nextWatcher(t)
+
+	// change is missed on the watch, the error event reloads the target
+	err := fc.Update(context.Background(), newConfigMap("true"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher.Error(&metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonExpired, Code: 410})
+
+	expectResult(t, result, false)
+
+	// watch restarted after the reload
+	fc.nextWatcher(t)
+}
+
+func TestWaitForExpectedSuccessCount(t *testing.T) {
+	fc := newFakeWatchClient(t, newConfigMap("true"))
+	tc := testTC
+	tc.ExpectedSuccessCount = 3
+	startTime := time.Now()
+	result := waitFor(fc, isReady, tc)
+
+	// initial state counted once, confirmed on the scan intervals
+	expectResult(t, result, false)
+	if timeTaken := time.Since(startTime); timeTaken < tc.ScanInterval*2 {
+		t.Errorf("success should be confirmed on the scan intervals, timeTaken:%s", timeTaken)
+	}
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	fc := newFakeWatchClient(t, newConfigMap("false"))
+	tc := testTC
+	tc.Timeout = time.Millisecond * 200
+	result := waitFor(fc, isReady, tc)
+
+	expectResult(t, result, true)
+}
+
+func TestWaitForPollingFallback(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithObjects(newConfigMap("false")).Build()
+	result := waitFor(&pollingClient{Client: fakeClient}, isReady, testTC)
+
+	time.Sleep(testTC.ScanInterval * 3)
+	err := fakeClient.Update(context.Background(), newConfigMap("true"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectResult(t, result, false)
+}
+
+func TestWaitForPollingTimeout(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithObjects(newConfigMap("false")).Build()
+	tc := testTC
+	tc.Timeout = time.Millisecond * 200
+	result := waitFor(&pollingClient{Client: fakeClient}, isReady, tc)
+
+	expectResult(t, result, true)
+}
@@ -11,3 +11,9 @@ type Plugin interface {
 	Close() error
 	Execute(task *templateTY.Task) (interface{}, error)
 }
+
+// TaskObserver is an optional interface of the plugins
+// gets notified after every task execution, on all the providers
+type TaskObserver interface {
+	TaskCompleted(task *templateTY.Task)
+}